@@ -1,10 +1,18 @@
 package app
 
 import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 
 	"github.com/raphi011/scores-api/events"
+	volleynet_client "github.com/raphi011/scores-api/volleynet/client"
 )
 
 // App wraps all the services and configuration needed
@@ -13,8 +21,11 @@ type App struct {
 	conf        *oauth2.Config
 	services    *handlerServices
 	eventBroker *events.Broker
+	eventLog    bool
 	version     string
 	production  bool
+
+	volleynetOptions []volleynet_client.Option
 }
 
 // Option is used to configure a new Router.
@@ -31,13 +42,85 @@ func New(opts ...Option) *App {
 	return app
 }
 
-// Run builds the router and opens the port.
+// jobsShutdownTimeout limits how long the running jobs are waited
+// for on shutdown, requestsShutdownTimeout limits how long the
+// in-flight requests are drained once the event streams are closed.
+const (
+	jobsShutdownTimeout     = 30 * time.Second
+	requestsShutdownTimeout = 30 * time.Second
+)
+
+// Run builds the router and serves the api until the process receives
+// SIGINT or SIGTERM, then it shuts down gracefully.
 func (r *App) Run() {
-	router := r.Build()
+	server := &http.Server{
+		Addr:    address(),
+		Handler: r.Build(),
+	}
+
+	serveErr := make(chan error, 1)
+
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
 
-	err := router.Run()
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
-	if err != nil {
+	select {
+	case err := <-serveErr:
 		zap.S().Errorf("could not start router: %+v", err)
+		return
+	case sig := <-quit:
+		zap.S().Infof("received %s, shutting down", sig)
 	}
+
+	r.shutdown(server)
+}
+
+// shutdown stops accepting new requests and drains the in-flight requests
+// while it waits for the running jobs, so their events are still published.
+// Then it closes the event streams, which are drained as well.
+func (r *App) shutdown(server *http.Server) {
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	drained := make(chan error, 1)
+
+	go func() {
+		drained <- server.Shutdown(requestsCtx)
+	}()
+
+	if r.services != nil {
+		jobsCtx, cancelJobs := context.WithTimeout(context.Background(), jobsShutdownTimeout)
+
+		if err := r.services.JobManager.Shutdown(jobsCtx); err != nil {
+			zap.S().Warnf("could not wait for the running jobs: %v", err)
+		}
+
+		cancelJobs()
+	}
+
+	if r.eventBroker != nil {
+		// ends the event streams and the webhooks
+		r.eventBroker.Close()
+	}
+
+	// the requests get their own deadline once the jobs are done
+	timer := time.AfterFunc(requestsShutdownTimeout, cancelRequests)
+	defer timer.Stop()
+
+	if err := <-drained; err != nil {
+		zap.S().Warnf("could not drain the in-flight requests: %v", err)
+	}
+}
+
+// address returns the address the api listens on, like gin it
+// uses the `PORT` environment variable or defaults to ":8080".
+func address() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+
+	return ":8080"
 }
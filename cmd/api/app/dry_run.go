@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/raphi011/scores-api/volleynet/sync"
+)
+
+// SyncDryRun runs the ladder and tournament syncs of the cron jobs without
+// persisting anything and writes the diff of what would change to `w`.
+func (r *App) SyncDryRun(ctx context.Context, w io.Writer) error {
+	ladderJob := r.ladderJob()
+	tournamentsJob := r.tournamentsJob()
+
+	diff := sync.NewDiff()
+
+	ladderDiff, err := ladderJob.DryRun(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	diff.Merge(ladderDiff)
+
+	// tournaments that could not be loaded are missing in the diff,
+	// the error is returned after the diff has been written
+	tournamentsDiff, tournamentsErr := tournamentsJob.DryRun(ctx)
+
+	if _, ok := tournamentsErr.(sync.TournamentErrors); tournamentsErr != nil && !ok {
+		return tournamentsErr
+	}
+
+	diff.Merge(tournamentsDiff)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(diff); err != nil {
+		return err
+	}
+
+	return tournamentsErr
+}
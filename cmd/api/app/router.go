@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
@@ -28,16 +30,19 @@ func (r *App) Build() *gin.Engine {
 	authHandler := route.AuthHandler(
 		s.User,
 		s.Password,
+		s.VolleynetSessions,
 		r.conf,
 	)
 
-	playerHandler := route.PlayerHandler(s.Volleynet, s.User)
-	tournamentHandler := route.TournamentHandler(s.Volleynet, s.VolleynetClient, s.User)
-	scrapeHandler := route.ScrapeHandler(s.JobManager)
+	playerHandler := route.PlayerHandler(s.Volleynet, s.VolleynetClient, s.VolleynetSessions, s.User)
+	tournamentHandler := route.TournamentHandler(s.Volleynet, s.VolleynetClient, s.VolleynetSessions, s.User)
+	scrapeHandler := route.ScrapeHandler(s.JobManager, s.JobRuns, s.ScrapeDiagnostics, s.Scrape)
 	infoHandler := route.InfoHandler(r.version)
 	adminHandler := route.AdminHandler(s.User)
+	webhookHandler := route.WebhookHandler(s.Webhooks)
 	debugHandler := route.DebugHandler(s.User)
 	cspHandler := route.CspHandler()
+	eventsHandler := route.EventsHandler(r.eventBroker, 15*time.Second)
 
 	// Generate keys on startup for HMAC signing + encryption.
 	// This means that on every restart previously authenticated
@@ -76,8 +81,14 @@ func (r *App) Build() *gin.Engine {
 		auth.GET("/filters", tournamentHandler.GetFilterOptions)
 		auth.GET("/tournaments", tournamentHandler.GetTournaments)
 		auth.GET("/tournaments/:tournamentID", tournamentHandler.GetTournament)
+		auth.GET("/tournaments/:tournamentID/history", tournamentHandler.GetTournamentHistory)
+		auth.POST("/tournaments/:tournamentID/withdraw", tournamentHandler.PostWithdrawal)
 		auth.POST("/signup", tournamentHandler.PostSignup)
 
+		if r.eventBroker != nil {
+			auth.GET("/events/stream", eventsHandler.GetStream)
+		}
+
 		auth.GET("/ladder", playerHandler.GetLadder)
 		auth.GET("/players/search", playerHandler.GetSearchPlayers)
 		auth.GET("/players/partners/:playerID", playerHandler.GetPartners)
@@ -90,6 +101,16 @@ func (r *App) Build() *gin.Engine {
 
 		admin.GET("/users", adminHandler.GetUsers)
 		admin.POST("/users", adminHandler.PostUser)
+
+		if r.eventBroker != nil {
+			admin.POST("/events/replay", eventsHandler.PostReplay)
+		}
+
+		admin.GET("/webhooks", webhookHandler.GetWebhooks)
+		admin.POST("/webhooks", webhookHandler.PostWebhook)
+		admin.DELETE("/webhooks/:webhookID", webhookHandler.DeleteWebhook)
+		admin.GET("/webhooks/:webhookID/deliveries", webhookHandler.GetDeliveries)
+		admin.POST("/webhook-deliveries/:deliveryID/retry", webhookHandler.PostRetryDelivery)
 	}
 
 	if !r.production {
@@ -102,6 +123,15 @@ func (r *App) Build() *gin.Engine {
 	volleynetAdmin := admin.Group("/volleynet")
 
 	volleynetAdmin.GET("/scrape/report", scrapeHandler.GetReport)
+	volleynetAdmin.GET("/scrape/diagnostics", scrapeHandler.GetDiagnostics)
+	volleynetAdmin.GET("/scrape/runs", scrapeHandler.GetRuns)
+	volleynetAdmin.GET("/scrape/dry-run", scrapeHandler.GetDryRun)
+	volleynetAdmin.POST("/jobs/:name/run", scrapeHandler.PostRunJob)
+	volleynetAdmin.POST("/jobs/:name/pause", scrapeHandler.PostPauseJob)
+	volleynetAdmin.POST("/jobs/:name/resume", scrapeHandler.PostResumeJob)
+	volleynetAdmin.POST("/jobs/:name/stop", scrapeHandler.PostStopJob)
+	volleynetAdmin.GET("/job-runs", scrapeHandler.GetJobRuns)
+	volleynetAdmin.GET("/job-runs/:runID", scrapeHandler.GetJobRun)
 
 	return router
 }
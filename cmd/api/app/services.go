@@ -1,23 +1,33 @@
 package app
 
 import (
+	"time"
+
 	"github.com/raphi011/scores-api/job"
 	"github.com/raphi011/scores-api/repo"
 	"github.com/raphi011/scores-api/services"
 	volleynet_client "github.com/raphi011/scores-api/volleynet/client"
+	"github.com/raphi011/scores-api/volleynet/scrape"
 	"github.com/raphi011/scores-api/volleynet/sync"
 )
 
 type handlerServices struct {
-	JobManager      *job.Manager
-	User            *services.User
-	Volleynet       *services.Volleynet
-	Scrape          *sync.Service
-	Password        services.Password
-	VolleynetClient volleynet_client.Client
+	JobManager        *job.Manager
+	User              *services.User
+	Volleynet         *services.Volleynet
+	Scrape            *sync.Service
+	ScrapeDiagnostics *scrape.Diagnostics
+	Password          services.Password
+	VolleynetClient   volleynet_client.Client
+	VolleynetSessions *services.VolleynetSessions
+	Webhooks          *services.Webhooks
+	EventLog          *services.EventLog
+	JobRuns           *services.JobRuns
 }
 
-func servicesFromRepository(repos *repo.Repositories) *handlerServices {
+// servicesFromRepository creates all services, the volleynet clients are
+// configured with `clientOpts`.
+func servicesFromRepository(repos *repo.Repositories, clientOpts ...volleynet_client.Option) *handlerServices {
 	password := &services.PBKDF2Password{
 		SaltBytes:  16,
 		Iterations: 10000,
@@ -36,25 +46,54 @@ func servicesFromRepository(repos *repo.Repositories) *handlerServices {
 		repos.TeamRepo,
 		repos.PlayerRepo,
 		repos.TournamentRepo,
+		repos.ChangeRepo,
 		metrics,
 	)
 
+	jobRuns := &services.JobRuns{Repo: repos.JobRunRepo}
+
 	manager := job.NewManager()
+	manager.Recorder = jobRuns
+	manager.Locker = &services.JobLeases{Repo: repos.JobLeaseRepo}
+
+	diagnostics := scrape.NewDiagnostics("")
+
+	// volleynet.at must never be hit by more than 5 requests per second,
+	// `clientOpts` may replace the limiter
+	limiter := volleynet_client.NewRateLimiter(5, 5)
 
 	scrapeService := &sync.Service{
+		MatchRepo:      repos.MatchRepo,
 		PlayerRepo:     repos.PlayerRepo,
 		TeamRepo:       repos.TeamRepo,
 		TournamentRepo: repos.TournamentRepo,
+		SyncRunRepo:    repos.SyncRunRepo,
+		ChangeRepo:     repos.ChangeRepo,
+		UnitOfWork:     repos.UnitOfWork,
 
-		Client: volleynet_client.Default(),
+		Client: volleynet_client.New(append([]volleynet_client.Option{
+			volleynet_client.WithDiagnostics(diagnostics),
+			volleynet_client.WithRateLimiter(limiter),
+		}, clientOpts...)...),
+		Workers: 4,
 	}
 
+	volleynetClient := volleynet_client.New(append([]volleynet_client.Option{
+		volleynet_client.WithRateLimiter(limiter),
+	}, clientOpts...)...)
+
 	s := &handlerServices{
-		Scrape:     scrapeService,
-		Volleynet:  volleynetService,
-		Password:   password,
-		User:       userService,
-		JobManager: manager,
+		Scrape:            scrapeService,
+		ScrapeDiagnostics: diagnostics,
+		Volleynet:         volleynetService,
+		Password:          password,
+		User:              userService,
+		JobManager:        manager,
+		VolleynetClient:   volleynetClient,
+		VolleynetSessions: services.NewVolleynetSessions(15 * time.Minute),
+		Webhooks:          services.NewWebhooksService(repos.WebhookRepo, repos.DeliveryRepo),
+		EventLog:          &services.EventLog{Repo: repos.EventLogRepo},
+		JobRuns:           jobRuns,
 	}
 
 	return s
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/raphi011/scores-api/job"
 	"github.com/raphi011/scores-api/repo"
 	"github.com/raphi011/scores-api/repo/sql"
+	volleynet_client "github.com/raphi011/scores-api/volleynet/client"
 	"github.com/raphi011/scores-api/volleynet/sync"
 	"go.uber.org/zap"
 )
@@ -48,7 +50,47 @@ func WithRepository(provider, connectionString string) Option {
 			zap.S().Fatalf("Could not initialize repository: %s", err)
 		}
 
-		r.services = servicesFromRepository(repos)
+		r.services = servicesFromRepository(repos, r.volleynetOptions...)
+	}
+}
+
+// WithVolleynetURL connects the volleynet clients to `url` instead of
+// volleynet.at, e.g. to a fake volleynet server. It must be passed
+// before the repository options.
+func WithVolleynetURL(url string) Option {
+	return func(r *App) {
+		if url == "" {
+			return
+		}
+
+		r.volleynetOptions = append(r.volleynetOptions, volleynet_client.WithURLs(url, url))
+	}
+}
+
+// WithVolleynetRateLimit limits the requests of the volleynet clients to
+// `rate` per second with bursts of up to `burst` requests. It must be passed
+// before the repository options.
+func WithVolleynetRateLimit(rate float64, burst int) Option {
+	return func(r *App) {
+		limiter := volleynet_client.NewRateLimiter(rate, burst)
+
+		r.volleynetOptions = append(r.volleynetOptions, volleynet_client.WithRateLimiter(limiter))
+	}
+}
+
+// WithSyncWorkers sets the # of tournaments and player profiles that are
+// loaded concurrently by the volleynet sync.
+func WithSyncWorkers(workers int) Option {
+	return func(r *App) {
+		r.services.Scrape.Workers = workers
+	}
+}
+
+// WithScrapeSnapshotDir saves the html of scraped pages that look
+// structurally wrong to `dir`.
+func WithScrapeSnapshotDir(dir string) Option {
+	return func(r *App) {
+		r.services.ScrapeDiagnostics.SnapshotDir = dir
 	}
 }
 
@@ -72,22 +114,44 @@ func WithTestRepository(t testing.TB) Option {
 	return func(r *App) {
 		repos, _ := sql.RepositoriesTest(t)
 
-		r.services = servicesFromRepository(repos)
+		r.services = servicesFromRepository(repos, r.volleynetOptions...)
+
+	}
+}
 
+// WithEventLog persists all published events in the repository if `enabled`
+// is set, so they can be resumed and replayed after a restart. It must be
+// passed before the eventqueue option.
+func WithEventLog(enabled bool) Option {
+	return func(r *App) {
+		r.eventLog = enabled
 	}
 }
 
-// WithEventQueue configures the eventqueue.
+// WithEventQueue configures the eventqueue and publishes the
+// sync events to it, it must be passed after the repository options.
 func WithEventQueue() Option {
 	return func(r *App) {
 		r.eventBroker = &events.Broker{}
 
+		if r.services != nil {
+			if r.eventLog {
+				r.eventBroker.Store = r.services.EventLog
+				// reconnecting streams don't load more than a day of events
+				r.eventBroker.MaxResumeAge = 24 * time.Hour
+			}
+
+			r.services.Scrape.Subscriptions = r.eventBroker
+
+			go r.services.Webhooks.Run(context.Background(), r.eventBroker, sync.EventsType, time.Second)
+		}
+
 		// we never unsubcribe
-		events, _ := r.eventBroker.Subscribe(sync.ScrapeEventsType)
+		events, _ := r.eventBroker.Subscribe(sync.EventsType)
 
 		go func() {
 			for event := range events {
-				zap.S().Debugf("scrape event: %v", event)
+				zap.S().Debugf("volleynet event %s: %v", event.Name, event.Body)
 			}
 		}()
 
@@ -96,34 +160,79 @@ func WithEventQueue() Option {
 	}
 }
 
+var (
+	syncGenders = []string{"M", "W"}
+	syncLeagues = []string{"AMATEUR TOUR", "PRO TOUR", "JUNIOR TOUR"}
+)
+
+func (r *App) ladderJob() cron.LadderJob {
+	return cron.LadderJob{
+		SyncService: r.services.Scrape,
+		Genders:     syncGenders,
+	}
+}
+
+func (r *App) tournamentsJob() cron.TournamentsJob {
+	return cron.TournamentsJob{
+		SyncService: r.services.Scrape,
+		Genders:     syncGenders,
+		Leagues:     syncLeagues,
+		Season:      time.Now().Year(),
+	}
+}
+
 // WithCron enable cron jobs.
 func WithCron() Option {
 	return func(r *App) {
-		ladderJob := cron.LadderJob{
-			SyncService: r.services.Scrape,
-			Genders:     []string{"M", "W"},
-		}
+		ladderJob := r.ladderJob()
 
-		tournamentsJob := cron.TournamentsJob{
+		playerProfilesJob := cron.PlayerProfilesJob{
 			SyncService: r.services.Scrape,
-			Genders:     []string{"M", "W"},
-			Leagues:     []string{"AMATEUR TOUR", "PRO TOUR", "JUNIOR TOUR"},
-			Season:      time.Now().Year(),
+			Genders:     syncGenders,
 		}
 
+		tournamentsJob := r.tournamentsJob()
+
 		lastYearsTournamentsJob := tournamentsJob
 		lastYearsTournamentsJob.Season = lastYearsTournamentsJob.Season - 1
 
+		vienna, err := time.LoadLocation("Europe/Vienna")
+
+		if err != nil {
+			zap.S().Warnf("Could not load timezone Europe/Vienna: %v, using UTC", err)
+			vienna = time.UTC
+		}
+
+		// volleynet.at is flaky at times, failed syncs are retried
+		// and the jobs recover after they have failed too often
+		retry := &job.RetryPolicy{
+			Delay:    30 * time.Second,
+			MaxDelay: 10 * time.Minute,
+			Jitter:   0.2,
+			Cooldown: 1 * time.Hour,
+		}
+
 		r.services.JobManager.Start(
 			job.Job{
 				Name:        "Players",
 				MaxFailures: 3,
 				Interval:    1 * time.Hour,
+				Timeout:     30 * time.Minute,
+				Retry:       retry,
 				Do:          ladderJob.Do,
 			},
+			job.Job{
+				Name:        "Player profiles",
+				MaxFailures: 3,
+				Schedule:    job.MustCron("0 4 * * *", vienna), // every night at 4:00
+				Timeout:     2 * time.Hour,
+				Retry:       retry,
+				Do:          playerProfilesJob.Do,
+			},
 			job.Job{
 				Name:    "Last years tournaments",
 				MaxRuns: 1, // only run once on startup
+				Timeout: 1 * time.Hour,
 				Do:      tournamentsJob.Do,
 			},
 			job.Job{
@@ -131,6 +240,8 @@ func WithCron() Option {
 				MaxFailures: 3,
 				Interval:    5 * time.Minute,
 				Delay:       1 * time.Minute,
+				Timeout:     15 * time.Minute,
+				Retry:       retry,
 				Do:          tournamentsJob.Do,
 			},
 		)
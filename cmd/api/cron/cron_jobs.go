@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"context"
 	"time"
 
 	"github.com/raphi011/scores-api/job"
@@ -20,13 +21,58 @@ type LadderJob struct {
 }
 
 // Do runs the scrape job.
-func (j *LadderJob) Do() error {
+func (j *LadderJob) Do(ctx context.Context) error {
 	for _, gender := range j.Genders {
-		_, err := j.SyncService.Ladder(gender)
+		report, err := j.SyncService.Ladder(ctx, gender)
 
 		if err != nil {
 			return err
 		}
+
+		job.Logger(ctx).Infof("synced the %s ladder: %d new, %d updated players", gender, report.NewPlayers, report.UpdatedPlayers)
+	}
+
+	return nil
+}
+
+// DryRun runs the scrape job without persisting the changes
+// and returns the diff of what would change.
+func (j *LadderJob) DryRun(ctx context.Context) (*sync.Diff, error) {
+	diff := sync.NewDiff()
+
+	for _, gender := range j.Genders {
+		d, err := j.SyncService.LadderDryRun(ctx, gender)
+
+		if err != nil {
+			return nil, err
+		}
+
+		diff.Merge(d)
+	}
+
+	return diff, nil
+}
+
+// PlayerProfilesJob is a job that scrapes the profile pages of ranked players.
+type PlayerProfilesJob struct {
+	SyncService *sync.Service
+	Genders     []string
+}
+
+// Do runs the scrape job. Profiles that could not be synced are logged and
+// synced again on the next run, the job only fails if no profile of a
+// gender could be synced.
+func (j *PlayerProfilesJob) Do(ctx context.Context) error {
+	for _, gender := range j.Genders {
+		report, err := j.SyncService.PlayerProfiles(ctx, gender)
+
+		if errs, ok := err.(sync.PlayerErrors); ok && report.UpdatedPlayers > 0 {
+			job.Logger(ctx).Warnf("could not sync all %s player profiles: %v", gender, errs)
+		} else if err != nil {
+			return err
+		}
+
+		job.Logger(ctx).Infof("synced the %s player profiles: %d updated players, %d new clubs", gender, report.UpdatedPlayers, report.NewClubs)
 	}
 
 	return nil
@@ -43,17 +89,65 @@ type TournamentsJob struct {
 	Season      int
 }
 
-// Do runs the scrape job.
-func (j *TournamentsJob) Do() error {
+// Do runs the scrape job. Tournaments that could not be synced don't stop
+// the job, the remaining leagues and genders are synced and the errors are
+// returned as `sync.TournamentErrors` at the end.
+func (j *TournamentsJob) Do(ctx context.Context) error {
+	tournamentErrors := sync.TournamentErrors{}
+
 	for _, league := range j.Leagues {
 		for _, gender := range j.Genders {
-			err := j.SyncService.Tournaments(gender, league, j.Season)
+			err := j.SyncService.Tournaments(ctx, gender, league, j.Season)
+
+			if errs, ok := err.(sync.TournamentErrors); ok {
+				job.Logger(ctx).Warnf("could not sync all %s %s tournaments of %d: %v", gender, league, j.Season, errs)
+
+				for id, err := range errs {
+					tournamentErrors[id] = err
+				}
 
-			if err != nil {
+				continue
+			} else if err != nil {
 				return err
 			}
+
+			job.Logger(ctx).Infof("synced the %s %s tournaments of %d", gender, league, j.Season)
 		}
 	}
 
+	if len(tournamentErrors) > 0 {
+		return tournamentErrors
+	}
+
 	return nil
 }
+
+// DryRun runs the scrape job without persisting the changes and returns
+// the diff of what would change, tournaments that could not be loaded
+// are missing in the diff and are returned as `sync.TournamentErrors`.
+func (j *TournamentsJob) DryRun(ctx context.Context) (*sync.Diff, error) {
+	diff := sync.NewDiff()
+	tournamentErrors := sync.TournamentErrors{}
+
+	for _, league := range j.Leagues {
+		for _, gender := range j.Genders {
+			d, err := j.SyncService.TournamentsDryRun(ctx, gender, league, j.Season)
+
+			if errs, ok := err.(sync.TournamentErrors); ok {
+				for id, err := range errs {
+					tournamentErrors[id] = err
+				}
+			} else if err != nil {
+				return nil, err
+			}
+
+			diff.Merge(d)
+		}
+	}
+
+	if len(tournamentErrors) > 0 {
+		return diff, tournamentErrors
+	}
+
+	return diff, nil
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
+	"os"
 
 	"github.com/raphi011/scores-api/cmd/api/app"
 )
@@ -14,15 +17,44 @@ func main() {
 	gSecret := flag.String("gauth", "./client_secret.json", "Path to google oauth secret")
 	mode := flag.String("mode", "production", "debug or production")
 	host := flag.String("backendurl", "https://localhost", "backend url")
+	volleynetURL := flag.String("volleynet", "", "url of the volleynet server to use instead of volleynet.at, e.g. a fake volleynet server")
+	snapshotDir := flag.String("snapshots", "", "directory to save snapshots of malformed volleynet pages to")
+	rateLimit := flag.Float64("volleynet-rate", 5, "max # of requests per second to volleynet")
+	rateBurst := flag.Int("volleynet-burst", 5, "max # of requests to volleynet in a burst")
+	syncWorkers := flag.Int("sync-workers", 4, "# of tournaments and player profiles that are loaded concurrently")
+
+	eventLog := flag.Bool("event-log", true, "persist published events so clients can resume them after a restart")
+	dryRun := flag.Bool("dry-run", false, "run the volleynet sync without persisting anything, print the diff and exit")
 
 	flag.Parse()
 
+	if *dryRun {
+		r := app.New(
+			app.WithVolleynetURL(*volleynetURL),
+			app.WithVolleynetRateLimit(*rateLimit, *rateBurst),
+			app.WithRepository(*dbProvider, *connectionString),
+			app.WithScrapeSnapshotDir(*snapshotDir),
+			app.WithSyncWorkers(*syncWorkers),
+		)
+
+		if err := r.SyncDryRun(context.Background(), os.Stdout); err != nil {
+			log.Fatalf("dry run failed: %v", err)
+		}
+
+		return
+	}
+
 	r := app.New(
 		app.WithVersion(version),
 		app.WithMode(*mode),
+		app.WithVolleynetURL(*volleynetURL),
+		app.WithVolleynetRateLimit(*rateLimit, *rateBurst),
 		app.WithRepository(*dbProvider, *connectionString),
+		app.WithScrapeSnapshotDir(*snapshotDir),
+		app.WithSyncWorkers(*syncWorkers),
 		app.WithCron(),
 		app.WithOAuth(*gSecret, *host),
+		app.WithEventLog(*eventLog),
 		app.WithEventQueue(),
 	)
 
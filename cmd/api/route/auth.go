@@ -44,18 +44,25 @@ func randToken() string {
 }
 
 // AuthHandler is the constructor for the Auth routes handler.
-func AuthHandler(userService *services.User, passwordService services.Password, conf *oauth2.Config) Auth {
+func AuthHandler(
+	userService *services.User,
+	passwordService services.Password,
+	volleynetSessions *services.VolleynetSessions,
+	conf *oauth2.Config,
+) Auth {
 	return Auth{
-		userService:     userService,
-		passwordService: passwordService,
-		conf:            conf,
+		userService:       userService,
+		passwordService:   passwordService,
+		volleynetSessions: volleynetSessions,
+		conf:              conf,
 	}
 }
 
 // Auth handles the authentication routes.
 type Auth struct {
-	userService     *services.User
-	passwordService services.Password
+	userService       *services.User
+	passwordService   services.Password
+	volleynetSessions *services.VolleynetSessions
 
 	conf *oauth2.Config
 }
@@ -207,9 +214,15 @@ func newStateToken(session sessions.Session) string {
 	return state
 }
 
-// PostLogout handles the logout route.
+// PostLogout handles the logout route, the volleynet session of the user is
+// removed as well.
 func (a *Auth) PostLogout(c *gin.Context) {
 	session := sessions.Default(c)
+
+	if userID, ok := session.Get("user-id").(*uuid.UUID); ok && a.volleynetSessions != nil {
+		a.volleynetSessions.Delete(*userID)
+	}
+
 	session.Clear()
 
 	loginRoute := ""
@@ -0,0 +1,57 @@
+package route_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/securecookie"
+
+	"github.com/raphi011/scores-api/cmd/api/route"
+	"github.com/raphi011/scores-api/services"
+	"github.com/raphi011/scores-api/volleynet/mocks"
+)
+
+func TestLogoutRemovesVolleynetSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+
+	volleynetSessions := services.NewVolleynetSessions(15 * time.Minute)
+	volleynetSessions.Set(userID, &mocks.ClientMock{})
+
+	authHandler := route.AuthHandler(nil, nil, volleynetSessions, nil)
+
+	router := gin.New()
+	router.Use(sessions.Sessions("session", cookie.NewStore(securecookie.GenerateRandomKey(32))))
+	router.POST("/login", func(c *gin.Context) {
+		session := sessions.Default(c)
+		session.Set("user-id", &userID)
+		session.Save()
+	})
+	router.POST("/logout", authHandler.PostLogout)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	for _, c := range w.Result().Cookies() {
+		req.AddCookie(c)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST /logout, want status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if _, ok := volleynetSessions.Get(userID); ok {
+		t.Error("POST /logout, want the volleynet session to be removed")
+	}
+}
@@ -0,0 +1,239 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/cmd/api/logger"
+	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/volleynet/sync"
+)
+
+// EventsHandler is the constructor for the Events routes handler,
+// a comment is sent to every stream every `heartbeat` to keep the
+// connection open.
+func EventsHandler(broker *events.Broker, heartbeat time.Duration) Events {
+	return Events{
+		broker:    broker,
+		heartbeat: heartbeat,
+	}
+}
+
+// Events wraps the depdencies of the EventsHandler.
+type Events struct {
+	broker    *events.Broker
+	heartbeat time.Duration
+}
+
+// eventFilter selects the events that are sent to a stream.
+type eventFilter struct {
+	names        []string
+	tournamentID int
+	gender       string
+}
+
+func (f *eventFilter) matches(event events.Event) bool {
+	matchesName := false
+
+	for _, name := range f.names {
+		if events.Matches(name, event.Name) {
+			matchesName = true
+			break
+		}
+	}
+
+	if !matchesName {
+		return false
+	}
+
+	tournamentID, gender := eventAttributes(event)
+
+	if f.tournamentID != 0 && f.tournamentID != tournamentID {
+		return false
+	}
+
+	if f.gender != "" && f.gender != gender {
+		return false
+	}
+
+	return true
+}
+
+// eventAttributes returns the tournament id and the gender
+// of an event, if it has them.
+func eventAttributes(event events.Event) (tournamentID int, gender string) {
+	switch body := event.Body.(type) {
+	case sync.TournamentEvent:
+		return body.Tournament.ID, body.Tournament.Gender
+	case sync.TeamEvent:
+		return body.TournamentID, body.Gender
+	case sync.RankChangedEvent:
+		return 0, body.Gender
+	case json.RawMessage:
+		// events that were loaded from the event store
+		var attributes struct {
+			Tournament struct {
+				ID     int    `json:"id"`
+				Gender string `json:"gender"`
+			} `json:"tournament"`
+			TournamentID int    `json:"tournamentId"`
+			Gender       string `json:"gender"`
+		}
+
+		if json.Unmarshal(body, &attributes) != nil {
+			return 0, ""
+		}
+
+		if attributes.Tournament.ID != 0 {
+			return attributes.Tournament.ID, attributes.Tournament.Gender
+		}
+
+		return attributes.TournamentID, attributes.Gender
+	}
+
+	return 0, ""
+}
+
+// GetStream handles the Stream route that sends the selected events to the
+// client as server-sent events. The `events` query parameter is a comma
+// separated list of event names that may contain wildcards and defaults to all
+// volleynet events, `tournamentId` and `gender` only keep the events of a
+// tournament or gender. Events that were published after the `Last-Event-ID`
+// are sent first, as long as they are still in the broker's store or history
+// and not older than its `MaxResumeAge`.
+func (h *Events) GetStream(c *gin.Context) {
+	filter := &eventFilter{
+		names:  []string{sync.EventsType},
+		gender: c.Query("gender"),
+	}
+
+	if names := c.Query("events"); names != "" {
+		filter.names = strings.Split(names, ",")
+	}
+
+	if tournamentID := c.Query("tournamentId"); tournamentID != "" {
+		id, err := strconv.Atoi(tournamentID)
+
+		if err != nil {
+			responseBadRequest(c)
+			return
+		}
+
+		filter.tournamentID = id
+	}
+
+	var stream <-chan events.Event
+	var unsubscribe events.Unsubscribe
+
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		lastID, err := strconv.ParseUint(header, 10, 64)
+
+		if err != nil {
+			responseBadRequest(c)
+			return
+		}
+
+		stream, unsubscribe, err = h.broker.Resume(sync.EventsType, lastID)
+
+		if err != nil {
+			responseErr(c, err)
+			return
+		}
+	} else {
+		stream, unsubscribe = h.broker.Subscribe(sync.EventsType)
+	}
+
+	defer unsubscribe()
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Writer.WriteHeader(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+
+			if !filter.matches(event) {
+				continue
+			}
+
+			h.write(c, event)
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+		}
+
+		c.Writer.Flush()
+	}
+}
+
+func (h *Events) write(c *gin.Context, event events.Event) {
+	data, err := json.Marshal(event.Body)
+
+	if err != nil {
+		logger.Get(c).Warnf("could not marshal event %s: %v", event.Name, err)
+		return
+	}
+
+	// an event that could not be stored has no id, sending 0 would
+	// make the client resume from the start of the store
+	if event.ID != 0 {
+		fmt.Fprintf(c.Writer, "id: %d\n", event.ID)
+	}
+
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Name, data)
+}
+
+type postReplayDto struct {
+	From   time.Time `json:"from" binding:"required"`
+	Events string    `json:"events"`
+}
+
+type replayResult struct {
+	Replayed int `json:"replayed"`
+}
+
+// PostReplay publishes the stored events that were published at or after
+// `from` again, e.g. to deliver them to the webhooks once more. `events`
+// selects the replayed events and defaults to all volleynet events.
+func (h *Events) PostReplay(c *gin.Context) {
+	var dto postReplayDto
+
+	if err := c.ShouldBindWith(&dto, binding.JSON); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	if dto.Events == "" {
+		dto.Events = sync.EventsType
+	}
+
+	replayed, err := h.broker.Replay(dto.Events, dto.From)
+
+	if errors.Cause(err) == events.ErrNoStore {
+		writeResponse(c, http.StatusNotImplemented, nil, err.Error())
+		return
+	} else if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, replayResult{Replayed: replayed})
+}
@@ -0,0 +1,147 @@
+package route_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/raphi011/scores-api/cmd/api/route"
+	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+	"github.com/raphi011/scores-api/volleynet/sync"
+)
+
+func eventStreamServer(t *testing.T, broker *events.Broker) *httptest.Server {
+	t.Helper()
+
+	handler := route.EventsHandler(broker, 10*time.Millisecond)
+
+	router := gin.New()
+	router.GET("/events/stream", handler.GetStream)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// readStream returns the `id` and `event` lines of the stream until
+// `count` events or a heartbeat (if `heartbeat` is set) were received.
+func readStream(t *testing.T, url, lastEventID string, count int, heartbeat bool) []string {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	test.Check(t, "GET /events/stream err: %v", err)
+	defer resp.Body.Close()
+
+	test.Equal(t, "GET /events/stream want content type %q, got %q", "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := []string{}
+	scanner := bufio.NewScanner(resp.Body)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "id: "), strings.HasPrefix(line, "event: "):
+			lines = append(lines, line)
+		case line == ": heartbeat" && heartbeat:
+			return lines
+		}
+
+		if !heartbeat && len(lines) == 2*count {
+			return lines
+		}
+	}
+
+	t.Fatalf("GET /events/stream ended early: %v, got: %v", scanner.Err(), lines)
+
+	return nil
+}
+
+func tournamentEvent(name string, id int, gender string) events.Event {
+	return events.Event{
+		Name: name,
+		Body: sync.TournamentEvent{Tournament: volleynet.TournamentInfo{ID: id, Gender: gender}},
+	}
+}
+
+func TestEventStreamFilters(t *testing.T) {
+	broker := &events.Broker{}
+	server := eventStreamServer(t, broker)
+
+	done := make(chan []string)
+
+	go func() {
+		done <- readStream(t, server.URL+"/events/stream?gender=W&events=volleynet/tournament/*", "", 1, false)
+	}()
+
+	timeout := time.After(5 * time.Second)
+
+	// publish until the stream has subscribed
+	for {
+		broker.Publish(events.Event{Name: sync.StartScrapeEventType})
+		broker.Publish(tournamentEvent(sync.TournamentCreatedEventType, 1, "M"))
+		broker.Publish(tournamentEvent(sync.TournamentCanceledEventType, 2, "W"))
+
+		select {
+		case lines := <-done:
+			test.Equal(t, "GET /events/stream want event %q, got %q", "event: "+sync.TournamentCanceledEventType, lines[1])
+			return
+		case <-timeout:
+			t.Fatal("GET /events/stream did not receive the event")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestEventStreamLastEventID(t *testing.T) {
+	broker := &events.Broker{}
+	server := eventStreamServer(t, broker)
+
+	broker.Publish(tournamentEvent(sync.TournamentCreatedEventType, 1, "M"))
+	broker.Publish(tournamentEvent(sync.RegistrationOpenedEventType, 1, "M"))
+	broker.Publish(tournamentEvent(sync.RegistrationClosedEventType, 1, "M"))
+
+	lines := readStream(t, server.URL+"/events/stream?tournamentId=1", "1", 0, true)
+
+	want := []string{
+		"id: 2", "event: " + sync.RegistrationOpenedEventType,
+		"id: 3", "event: " + sync.RegistrationClosedEventType,
+	}
+
+	test.Compare(t, "GET /events/stream unexpected events:\n%s", want, lines)
+}
+
+func TestEventStreamRequiresLogin(t *testing.T) {
+	client := newTestClient(t)
+
+	w := client.get("/events/stream")
+
+	test.Equal(t, "GET /events/stream want status %d, got %d", http.StatusUnauthorized, w.Code)
+}
+
+func TestReplayWithoutEventLog(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/admin/events/replay", map[string]string{"from": "2020-01-01T00:00:00Z"})
+
+	test.Equal(t, "/admin/events/replay expected status %d, got %d", http.StatusNotImplemented, w.Code)
+}
@@ -11,21 +11,30 @@ import (
 
 	"github.com/raphi011/scores-api/repo"
 	"github.com/raphi011/scores-api/services"
-	"github.com/raphi011/scores-api/volleynet/client"
+	volleynet_client "github.com/raphi011/scores-api/volleynet/client"
 )
 
 // PlayerHandler is the constructor for the player routes handler.
-func PlayerHandler(volleynetService *services.Volleynet, userService *services.User) Player {
+func PlayerHandler(
+	volleynetService *services.Volleynet,
+	volleynetClient volleynet_client.Client,
+	volleynetSessions *services.VolleynetSessions,
+	userService *services.User,
+) Player {
 	return Player{
-		volleynetService: volleynetService,
-		userService:      userService,
+		volleynetService:  volleynetService,
+		volleynetClient:   volleynetClient,
+		volleynetSessions: volleynetSessions,
+		userService:       userService,
 	}
 }
 
 // Player wraps the dependencies of the PlayerHandler.
 type Player struct {
-	volleynetService *services.Volleynet
-	userService      *services.User
+	volleynetService  *services.Volleynet
+	volleynetClient   volleynet_client.Client
+	volleynetSessions *services.VolleynetSessions
+	userService       *services.User
 }
 
 // GetLadder returns the ladder of a gender.
@@ -69,8 +78,7 @@ func (h *Player) PostLogin(c *gin.Context) {
 		return
 	}
 
-	vnClient := client.Default()
-	loginData, err := vnClient.Login(login.Username, login.Password)
+	vnSession, loginData, err := h.volleynetClient.Login(c.Request.Context(), login.Username, login.Password)
 
 	if err != nil {
 		response(c, http.StatusUnauthorized, nil)
@@ -79,6 +87,8 @@ func (h *Player) PostLogin(c *gin.Context) {
 
 	session := sessions.Default(c)
 	userID := session.Get("user-id").(*uuid.UUID)
+
+	h.volleynetSessions.Set(*userID, vnSession)
 	user, err := h.userService.ByID(*userID)
 
 	if err != nil {
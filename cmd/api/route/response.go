@@ -9,6 +9,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/raphi011/scores-api"
 	"github.com/raphi011/scores-api/cmd/api/logger"
+	"github.com/raphi011/scores-api/job"
+	"github.com/raphi011/scores-api/volleynet/scrape"
 )
 
 func responseBadRequest(c *gin.Context) {
@@ -32,10 +34,17 @@ func extractErrorInformation(err error) (code int, message string) {
 
 	cause := errors.Cause(err)
 
-	if cause == scores.ErrNotFound {
+	if cause == scores.ErrNotFound || cause == job.ErrNotFound {
 		code = http.StatusNotFound
 	} else if cause == scores.ErrorUnauthorized {
 		code = http.StatusUnauthorized
+	} else if cause == scores.ErrorValidation {
+		code = http.StatusBadRequest
+	} else if cause == job.ErrInvalidState {
+		code = http.StatusConflict
+	} else if cause == scrape.ErrLoginRequired {
+		// the volleynet session has expired, not the session of the api
+		code = http.StatusBadGateway
 	}
 
 	if code == http.StatusInternalServerError {
@@ -2,21 +2,40 @@ package route
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/cmd/api/logger"
 	"github.com/raphi011/scores-api/job"
+	"github.com/raphi011/scores-api/services"
+	"github.com/raphi011/scores-api/volleynet"
+	"github.com/raphi011/scores-api/volleynet/scrape"
+	"github.com/raphi011/scores-api/volleynet/sync"
+)
+
+const (
+	defaultRunsPageSize = 25
+	maxRunsPageSize     = 100
 )
 
 // ScrapeHandler is the constructor for the Scrape routes handler.
-func ScrapeHandler(jobManager *job.Manager) Scrape {
+func ScrapeHandler(jobManager *job.Manager, jobRuns *services.JobRuns, diagnostics *scrape.Diagnostics, syncService *sync.Service) Scrape {
 	return Scrape{
-		jobManager: jobManager,
+		jobManager:  jobManager,
+		jobRuns:     jobRuns,
+		diagnostics: diagnostics,
+		syncService: syncService,
 	}
 }
 
 // Scrape wraps the depdencies of the ScrapeHandler.
 type Scrape struct {
-	jobManager *job.Manager
+	jobManager  *job.Manager
+	jobRuns     *services.JobRuns
+	diagnostics *scrape.Diagnostics
+	syncService *sync.Service
 }
 
 // GetReport handles the Report route that returns
@@ -27,42 +46,188 @@ func (h *Scrape) GetReport(c *gin.Context) {
 	response(c, http.StatusOK, execs)
 }
 
-// func (h *Scrape) run(c *gin.Context) {
-// 	jobName := c.Query("job")
+// GetDiagnostics handles the Diagnostics route that returns
+// all issues that occured while parsing volleynet pages.
+func (h *Scrape) GetDiagnostics(c *gin.Context) {
+	response(c, http.StatusOK, h.diagnostics.Issues())
+}
+
+type syncRunsPage struct {
+	Runs     []*volleynet.SyncRun `json:"runs"`
+	Page     int                  `json:"page"`
+	PageSize int                  `json:"pageSize"`
+	Total    int                  `json:"total"`
+}
+
+// GetRuns handles the Runs route that returns a page of past
+// sync runs, the latest run first.
+func (h *Scrape) GetRuns(c *gin.Context) {
+	page, pageSize, ok := runsPage(c)
+
+	if !ok {
+		responseBadRequest(c)
+		return
+	}
+
+	runs, total, err := h.syncService.Runs((page-1)*pageSize, pageSize)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, syncRunsPage{
+		Runs:     runs,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	})
+}
+
+type jobRunsPage struct {
+	Runs     []*scores.JobRun `json:"runs"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"pageSize"`
+	Total    int              `json:"total"`
+}
+
+// GetJobRuns handles the JobRuns route that returns a page of past job
+// runs without their logs, the latest run first. The `job` query
+// parameter only returns the runs of a single job.
+func (h *Scrape) GetJobRuns(c *gin.Context) {
+	page, pageSize, ok := runsPage(c)
+
+	if !ok {
+		responseBadRequest(c)
+		return
+	}
+
+	runs, total, err := h.jobRuns.Runs(c.Query("job"), (page-1)*pageSize, pageSize)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, jobRunsPage{
+		Runs:     runs,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	})
+}
+
+// GetJobRun handles the JobRun route that returns a job run with its log.
+func (h *Scrape) GetJobRun(c *gin.Context) {
+	runID, err := strconv.Atoi(c.Param("runID"))
 
-// 	exists := h.jobManager.HasJob(jobName)
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	run, err := h.jobRuns.Get(runID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, run)
+}
+
+// runsPage parses the `page` and `pageSize` query parameters.
+func runsPage(c *gin.Context) (page, pageSize int, ok bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	if err != nil || page < 1 {
+		return 0, 0, false
+	}
+
+	pageSize, err = strconv.Atoi(c.DefaultQuery("pageSize", strconv.Itoa(defaultRunsPageSize)))
+
+	if err != nil || pageSize < 1 || pageSize > maxRunsPageSize {
+		return 0, 0, false
+	}
 
-// 	if !exists {
-// 		response(c, http.StatusNotFound, nil)
-// 		return
-// 	}
+	return page, pageSize, true
+}
+
+// GetDryRun handles the DryRun route that runs a sync without persisting
+// anything and returns the diff of what would change, `type` is
+// either `tournaments` or `ladder`.
+func (h *Scrape) GetDryRun(c *gin.Context) {
+	gender := c.DefaultQuery("gender", "M")
+
+	var diff *sync.Diff
+	var err error
+
+	switch c.Query("type") {
+	case "tournaments":
+		season, convErr := strconv.Atoi(c.DefaultQuery("season", strconv.Itoa(time.Now().Year())))
+		league := c.Query("league")
+
+		if convErr != nil || league == "" {
+			responseBadRequest(c)
+			return
+		}
+
+		diff, err = h.syncService.TournamentsDryRun(c.Request.Context(), gender, league, season)
+
+		// tournaments that could not be loaded are missing in the diff
+		if _, ok := err.(sync.TournamentErrors); ok {
+			logger.Get(c).Warnf("dry run: %v", err)
+			err = nil
+		}
+	case "ladder":
+		diff, err = h.syncService.LadderDryRun(c.Request.Context(), gender)
+	default:
+		responseBadRequest(c)
+		return
+	}
 
-// 	err := h.jobManager.Run(jobName)
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, diff)
+}
 
-// 	if err != nil {
-// 		responseErr(c, err)
-// 		return
-// 	}
+// jobAction applies `action` to the job named by
+// the `name` path parameter and returns the job.
+func (h *Scrape) jobAction(c *gin.Context, action func(jobName string) error) {
+	jobName := c.Param("name")
 
-// 	response(c, http.StatusOK, nil)
-// }
+	if err := action(jobName); err != nil {
+		responseErr(c, err)
+		return
+	}
 
-// func (h *Scrape) stop(c *gin.Context) {
-// 	jobName := c.Query("job")
+	j, _ := h.jobManager.Job(jobName)
 
-// 	exists := h.jobManager.HasJob(jobName)
+	response(c, http.StatusOK, &j)
+}
 
-// 	if !exists {
-// 		response(c, http.StatusNotFound, nil)
-// 		return
-// 	}
+// PostRunJob handles the RunJob route that runs a job immediately,
+// e.g. to sync the results as soon as they are published.
+func (h *Scrape) PostRunJob(c *gin.Context) {
+	h.jobAction(c, h.jobManager.Trigger)
+}
 
-// 	err := h.jobManager.StopJob(jobName)
+// PostPauseJob handles the PauseJob route that skips
+// the runs of a job until it is resumed.
+func (h *Scrape) PostPauseJob(c *gin.Context) {
+	h.jobAction(c, h.jobManager.Pause)
+}
 
-// 	if err != nil {
-// 		responseErr(c, err)
-// 		return
-// 	}
+// PostResumeJob handles the ResumeJob route that continues
+// the schedule of a paused or stopped job.
+func (h *Scrape) PostResumeJob(c *gin.Context) {
+	h.jobAction(c, h.jobManager.Resume)
+}
 
-// 	response(c, http.StatusOK, nil)
-// }
+// PostStopJob handles the StopJob route that stops a job.
+func (h *Scrape) PostStopJob(c *gin.Context) {
+	h.jobAction(c, h.jobManager.Stop)
+}
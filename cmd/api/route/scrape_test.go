@@ -0,0 +1,53 @@
+package route_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestGetScrapeRuns(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/admin/volleynet/scrape/runs?page=1&pageSize=10")
+
+	test.Equal(t, "/admin/volleynet/scrape/runs expected status %d, got %d", http.StatusOK, w.Code)
+}
+
+func TestGetScrapeRunsInvalidPage(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/admin/volleynet/scrape/runs?page=0")
+
+	test.Equal(t, "/admin/volleynet/scrape/runs expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestRunUnknownJob(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/admin/volleynet/jobs/unknown/run", nil)
+
+	test.Equal(t, "/admin/volleynet/jobs/unknown/run expected status %d, got %d", http.StatusNotFound, w.Code)
+}
+
+func TestGetJobRuns(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/admin/volleynet/job-runs?job=Tournaments&page=1")
+
+	test.Equal(t, "/admin/volleynet/job-runs expected status %d, got %d", http.StatusOK, w.Code)
+}
+
+func TestGetUnknownJobRun(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/admin/volleynet/job-runs/1")
+
+	test.Equal(t, "/admin/volleynet/job-runs/1 expected status %d, got %d", http.StatusNotFound, w.Code)
+}
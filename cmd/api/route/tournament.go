@@ -12,28 +12,31 @@ import (
 	"github.com/raphi011/scores-api/cmd/api/logger"
 	"github.com/raphi011/scores-api/repo"
 	"github.com/raphi011/scores-api/services"
-	"github.com/raphi011/scores-api/volleynet/client"
 	volleynet_client "github.com/raphi011/scores-api/volleynet/client"
+	"github.com/raphi011/scores-api/volleynet/scrape"
 )
 
 //TournamentHandler is the constructor for the tournament routes handler.
 func TournamentHandler(
 	volleynetService *services.Volleynet,
 	volleynetClient volleynet_client.Client,
+	volleynetSessions *services.VolleynetSessions,
 	userService *services.User,
 ) Tournament {
 	return Tournament{
-		volleynetService: volleynetService,
-		volleynetClient:  volleynetClient,
-		userService:      userService,
+		volleynetService:  volleynetService,
+		volleynetClient:   volleynetClient,
+		volleynetSessions: volleynetSessions,
+		userService:       userService,
 	}
 }
 
 // Tournament wraps the depdencies of the TournamentHandler.
 type Tournament struct {
-	volleynetService *services.Volleynet
-	volleynetClient  volleynet_client.Client
-	userService      *services.User
+	volleynetService  *services.Volleynet
+	volleynetClient   volleynet_client.Client
+	volleynetSessions *services.VolleynetSessions
+	userService       *services.User
 }
 
 // GetTournaments queries all available tournaments.
@@ -99,6 +102,25 @@ func (h *Tournament) GetTournament(c *gin.Context) {
 	response(c, http.StatusOK, tournament)
 }
 
+// GetTournamentHistory loads the changes of a tournament and its teams.
+func (h *Tournament) GetTournamentHistory(c *gin.Context) {
+	tournamentID, err := strconv.Atoi(c.Param("tournamentID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	changes, err := h.volleynetService.TournamentHistory(tournamentID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, changes)
+}
+
 type signupForm struct {
 	Username     string `json:"username"`
 	Password     string `json:"password"`
@@ -107,7 +129,8 @@ type signupForm struct {
 	RememberMe   bool   `json:"rememberMe"`
 }
 
-// PostSignup allows a player to signup for a tournament.
+// PostSignup allows a player to signup for a tournament, if the
+// credentials are omitted the volleynet session of a previous login is used.
 func (h *Tournament) PostSignup(c *gin.Context) {
 	su := signupForm{}
 
@@ -116,28 +139,84 @@ func (h *Tournament) PostSignup(c *gin.Context) {
 		return
 	}
 
-	if su.Username == "" ||
-		su.Password == "" ||
-		su.PartnerID <= 0 ||
+	if su.PartnerID <= 0 ||
 		su.TournamentID <= 0 {
 
 		responseBadRequest(c)
 		return
 	}
 
-	vnClient := client.Default()
-	loginData, err := vnClient.Login(su.Username, su.Password)
+	vnSession, loginData, ok := h.volleynetSession(c, su.Username, su.Password)
 
-	if err != nil {
+	if !ok {
 		response(c, http.StatusUnauthorized, nil)
 		return
 	}
 
-	if su.RememberMe {
+	if su.RememberMe && loginData != nil {
 		h.rememberMe(c, su.Username, loginData.ID)
 	}
 
-	err = h.volleynetService.EnterTournament(su.PartnerID, su.TournamentID)
+	err := h.volleynetService.EnterTournament(c.Request.Context(), vnSession, su.PartnerID, su.TournamentID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, nil)
+}
+
+type withdrawalForm struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// PostWithdrawal withdraws the player from a tournament, if the
+// credentials are omitted the volleynet session of a previous login is used.
+func (h *Tournament) PostWithdrawal(c *gin.Context) {
+	tournamentID, err := strconv.Atoi(c.Param("tournamentID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	wf := withdrawalForm{}
+
+	// the credentials are optional so the body may be empty
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindWith(&wf, binding.JSON); err != nil {
+			responseBadRequest(c)
+			return
+		}
+	}
+
+	vnSession, loginData, ok := h.volleynetSession(c, wf.Username, wf.Password)
+
+	if !ok {
+		response(c, http.StatusUnauthorized, nil)
+		return
+	}
+
+	var playerID int
+
+	if loginData != nil {
+		playerID = loginData.ID
+	} else {
+		session := sessions.Default(c)
+		userID := session.Get("user-id").(*uuid.UUID)
+		user, err := h.userService.ByID(*userID)
+
+		if err != nil {
+			responseErr(c, err)
+			return
+		}
+
+		playerID = user.PlayerID
+	}
+
+	err = h.volleynetService.WithdrawFromTournament(c.Request.Context(), vnSession, playerID, tournamentID)
 
 	if err != nil {
 		responseErr(c, err)
@@ -147,6 +226,35 @@ func (h *Tournament) PostSignup(c *gin.Context) {
 	response(c, http.StatusOK, nil)
 }
 
+// volleynetSession logs the user in to volleynet if `username` and `password`
+// are set, else the session of a previous login is reused. `loginData` is
+// only returned for new logins.
+func (h *Tournament) volleynetSession(c *gin.Context, username, password string) (
+	vnSession volleynet_client.Client,
+	loginData *scrape.LoginData,
+	ok bool,
+) {
+	session := sessions.Default(c)
+	userID := session.Get("user-id").(*uuid.UUID)
+
+	if username == "" || password == "" {
+		vnSession, ok = h.volleynetSessions.Get(*userID)
+
+		return vnSession, nil, ok
+	}
+
+	vnSession, loginData, err := h.volleynetClient.Login(c.Request.Context(), username, password)
+
+	if err != nil {
+		logger.Get(c).Infof("volleynet login of %q failed: %v", username, err)
+		return nil, nil, false
+	}
+
+	h.volleynetSessions.Set(*userID, vnSession)
+
+	return vnSession, loginData, true
+}
+
 func (h *Tournament) rememberMe(
 	c *gin.Context,
 	userName string,
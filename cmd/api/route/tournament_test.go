@@ -139,3 +139,12 @@ func TestGetTournamentsWithoutFilters(t *testing.T) {
 
 	test.Equal(t, "/tournaments expected status %d, got %d", http.StatusOK, w.Code)
 }
+
+func TestGetHistoryOfUnknownTournament(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/tournaments/1/history")
+
+	test.Equal(t, "/tournaments/1/history expected status %d, got %d", http.StatusNotFound, w.Code)
+}
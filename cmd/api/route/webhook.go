@@ -0,0 +1,150 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/services"
+)
+
+const (
+	defaultDeliveriesPageSize = 25
+	maxDeliveriesPageSize     = 100
+)
+
+// WebhookHandler is the constructor for the Webhook routes handler.
+func WebhookHandler(webhookService *services.Webhooks) Webhook {
+	return Webhook{webhookService: webhookService}
+}
+
+// Webhook wraps the depdencies of the WebhookHandler.
+type Webhook struct {
+	webhookService *services.Webhooks
+}
+
+// GetWebhooks returns all registered webhooks.
+func (h *Webhook) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookService.All()
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, webhooks)
+}
+
+type postWebhookDto struct {
+	URL    string `json:"url" binding:"required"`
+	Events string `json:"events" binding:"required"`
+	Secret string `json:"secret"`
+}
+
+// createdWebhook is the only response that contains the secret of a webhook.
+type createdWebhook struct {
+	*scores.Webhook
+	Secret string `json:"secret"`
+}
+
+// PostWebhook registers a webhook, a secret is generated if none is passed.
+func (h *Webhook) PostWebhook(c *gin.Context) {
+	var dto postWebhookDto
+
+	if err := c.ShouldBindWith(&dto, binding.JSON); err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	webhook, err := h.webhookService.Create(dto.URL, dto.Events, dto.Secret)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusCreated, createdWebhook{Webhook: webhook, Secret: webhook.Secret})
+}
+
+// DeleteWebhook removes a webhook.
+func (h *Webhook) DeleteWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("webhookID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	if err = h.webhookService.Delete(webhookID); err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	responseNoContent(c)
+}
+
+type deliveriesPage struct {
+	Deliveries []*scores.WebhookDelivery `json:"deliveries"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"pageSize"`
+}
+
+// GetDeliveries returns a page of the delivery log of a
+// webhook, the latest delivery first.
+func (h *Webhook) GetDeliveries(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("webhookID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	if err != nil || page < 1 {
+		responseBadRequest(c)
+		return
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", strconv.Itoa(defaultDeliveriesPageSize)))
+
+	if err != nil || pageSize < 1 || pageSize > maxDeliveriesPageSize {
+		responseBadRequest(c)
+		return
+	}
+
+	deliveries, err := h.webhookService.Deliveries(webhookID, (page-1)*pageSize, pageSize)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, deliveriesPage{
+		Deliveries: deliveries,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// PostRetryDelivery queues a dead-lettered delivery to be sent again.
+func (h *Webhook) PostRetryDelivery(c *gin.Context) {
+	deliveryID, err := strconv.Atoi(c.Param("deliveryID"))
+
+	if err != nil {
+		responseBadRequest(c)
+		return
+	}
+
+	delivery, err := h.webhookService.Retry(deliveryID)
+
+	if err != nil {
+		responseErr(c, err)
+		return
+	}
+
+	response(c, http.StatusOK, delivery)
+}
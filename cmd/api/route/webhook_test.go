@@ -0,0 +1,48 @@
+package route_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestPostWebhook(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/admin/webhooks", map[string]string{
+		"url":    "https://example.com/hook",
+		"events": "volleynet/tournament/*",
+	})
+
+	test.Equal(t, "/admin/webhooks expected status %d, got %d", http.StatusCreated, w.Code)
+	test.Assert(t, "/admin/webhooks want the secret in the response, got: %s", strings.Contains(w.Body.String(), `"secret":"`), w.Body.String())
+
+	w = client.get("/admin/webhooks")
+
+	test.Equal(t, "/admin/webhooks expected status %d, got %d", http.StatusOK, w.Code)
+	test.Assert(t, "/admin/webhooks want no secrets in the response, got: %s", !strings.Contains(w.Body.String(), "secret"), w.Body.String())
+}
+
+func TestPostWebhookInvalidURL(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.post("/admin/webhooks", map[string]string{
+		"url":    "not a url",
+		"events": "volleynet/*",
+	})
+
+	test.Equal(t, "/admin/webhooks expected status %d, got %d", http.StatusBadRequest, w.Code)
+}
+
+func TestGetDeliveriesOfUnknownWebhook(t *testing.T) {
+	client := newTestClient(t)
+	client.login()
+
+	w := client.get("/admin/webhooks/1/deliveries")
+
+	test.Equal(t, "/admin/webhooks/1/deliveries expected status %d, got %d", http.StatusNotFound, w.Code)
+}
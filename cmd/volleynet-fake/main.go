@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raphi011/scores-api/volleynet/fake"
+)
+
+type accounts []string
+
+func (a *accounts) String() string {
+	return strings.Join(*a, ",")
+}
+
+func (a *accounts) Set(value string) error {
+	*a = append(*a, value)
+
+	return nil
+}
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	testdata := flag.String("testdata", "./volleynet/testdata", "path to the volleynet testdata directory")
+	season := flag.Int("season", time.Now().Year(), "season the tournaments are moved to")
+
+	var logins accounts
+	flag.Var(&logins, "account", "account that can login as user:password:playerID, can be repeated")
+
+	flag.Parse()
+
+	server, err := fake.Load(*testdata, *season)
+
+	if err != nil {
+		log.Fatalf("could not load testdata: %v", err)
+	}
+
+	for _, login := range logins {
+		parts := strings.Split(login, ":")
+
+		if len(parts) != 3 {
+			log.Fatalf("invalid account %q, must be user:password:playerID", login)
+		}
+
+		playerID, err := strconv.Atoi(parts[2])
+
+		if err != nil {
+			log.Fatalf("invalid playerID in account %q", login)
+		}
+
+		server.AddAccount(parts[0], parts[1], playerID)
+	}
+
+	log.Printf("fake volleynet listening on %s", *addr)
+
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
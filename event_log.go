@@ -0,0 +1,10 @@
+package scores
+
+// EventLogEntry is a published event in the event log, the `ID` is the
+// sequence number of the event and `Body` is json encoded.
+type EventLogEntry struct {
+	M
+	Track
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
@@ -4,19 +4,53 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
+
+	"go.uber.org/zap"
 )
 
-// Broker handles subscribing and publishing of events
+// Broker handles subscribing and publishing of events. Each subscription
+// has its own buffer, so a slow subscriber never blocks a publisher for
+// longer than its overflow policy allows. If a `Store` is set every event
+// is persisted before it is published and the store assigns the IDs,
+// otherwise only the latest events are kept in memory.
 type Broker struct {
+	Store Store
+	// MaxResumeAge limits how far back `Resume` goes, stored events that
+	// are older are not sent again. Zero means there is no limit.
+	MaxResumeAge time.Duration
+
 	subscribers sync.Map
+
+	mutex  sync.RWMutex
+	closed bool
+
+	historyMutex sync.Mutex
+	lastID       uint64
+	history      []Event // the latest events, oldest first
 }
 
+// historySize is the number of published events that are kept for `Since`.
+const historySize = 256
+
 // Publish publishes an event to all subscribers that are listening to an event
 func (b *Broker) Publish(event Event) {
 	if event.Name == "" {
 		return
 	}
 
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	b.deliver(b.record(event))
+}
+
+// deliver sends the event to all subscriptions that match its name.
+func (b *Broker) deliver(event Event) {
 	handlers := expandPossibleHandlers(event.Name)
 
 	for _, handler := range handlers {
@@ -32,14 +66,89 @@ func (b *Broker) Publish(event Event) {
 			panic(fmt.Sprintf("incompatible subscription for event %s", event.Name))
 		}
 
-		subscriptions.mutex.Lock()
+		for _, listener := range subscriptions.All() {
+			listener.deliver(event)
+		}
+	}
+}
+
+// record sets the ID of the event and adds it to the history. If the
+// event can't be stored it is published without an ID. The store is
+// written to without holding a lock, so a slow store only delays the
+// publisher of that event.
+func (b *Broker) record(event Event) Event {
+	if b.Store != nil {
+		id, err := b.Store.Append(event)
+
+		if err != nil {
+			metrics.storeErrors.Inc()
+			zap.S().Warnf("could not store event %s: %+v", event.Name, err)
+		}
+
+		event.ID = id
+
+		return event
+	}
+
+	b.historyMutex.Lock()
+	defer b.historyMutex.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+
+	if len(b.history) == historySize {
+		b.history = append(b.history[:0], b.history[1:]...)
+	}
+
+	b.history = append(b.history, event)
+
+	return event
+}
+
+// Since returns the published events with an ID greater than `id` that
+// match `eventName`, oldest first. At most one page of events is loaded from
+// the store, if `more` is set the following events are returned by calling
+// Since again with `next`. Without a store only the latest 256 events are kept.
+func (b *Broker) Since(eventName string, id uint64) (events []Event, next uint64, more bool, err error) {
+	return b.since(eventName, time.Time{}, id)
+}
+
+// historySince returns the events in the history with an
+// ID greater than `id` that match `eventName`.
+func (b *Broker) historySince(eventName string, id uint64) []Event {
+	b.historyMutex.Lock()
+	defer b.historyMutex.Unlock()
 
-		for _, listener := range subscriptions.listeners {
-			listener <- event
+	events := []Event{}
+
+	for _, event := range b.history {
+		if event.ID > id && Matches(eventName, event.Name) {
+			events = append(events, event)
 		}
+	}
+
+	return events
+}
+
+// Close closes all subscriptions, events that are published afterwards are
+// discarded and new subscriptions receive a closed channel.
+func (b *Broker) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 
-		subscriptions.mutex.Unlock()
+	if b.closed {
+		return
 	}
+
+	b.closed = true
+
+	b.subscribers.Range(func(_, s interface{}) bool {
+		for _, listener := range s.(*subscriptions).All() {
+			listener.close()
+		}
+
+		return true
+	})
 }
 
 // expandPossibleHandlers returns a list containing the eventName itself
@@ -72,25 +181,41 @@ func expandPossibleHandlers(eventName string) []string {
 	return handlers
 }
 
+// Matches returns true if a subscription to `eventName`, which may end
+// with a wildcard, receives events named `name`.
+func Matches(eventName, name string) bool {
+	for _, handler := range expandPossibleHandlers(name) {
+		if handler == eventName {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Subscribe subscribes to an event in the form of "some/interesting/event" or
 // (with wildcards) "some/interesting/*". Wildcards are only valid after directly
-// preceding slashes.
-func (b *Broker) Subscribe(eventName string) (<-chan Event, Unsubscribe) {
-	var subs *subscriptions
+// preceding slashes. By default up to 64 events are buffered and the oldest
+// event is dropped when the buffer is full.
+func (b *Broker) Subscribe(eventName string, opts ...SubscribeOption) (<-chan Event, Unsubscribe) {
+	listener := newSubscription(eventName, opts...)
 
-	messageChan := make(chan Event)
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
 
-	if s, found := b.subscribers.Load(eventName); found {
-		subs = s.(*subscriptions)
-	} else {
-		subs = &subscriptions{}
-		b.subscribers.Store(eventName, subs)
+	if b.closed {
+		listener.close()
+
+		return listener.events, func() {}
 	}
 
-	subs.Add(messageChan)
+	s, _ := b.subscribers.LoadOrStore(eventName, &subscriptions{})
+	subs := s.(*subscriptions)
+
+	subs.Add(listener)
 
-	return messageChan, func() {
-		subs.Remove(messageChan)
-		close(messageChan)
+	return listener.events, func() {
+		subs.Remove(listener)
+		listener.close()
 	}
 }
@@ -2,6 +2,9 @@ package events
 
 import (
 	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/raphi011/scores-api/test"
 )
@@ -19,3 +22,97 @@ func TestExpandPossibleHandlers(t *testing.T) {
 
 	test.Compare(t, "expandPossibleHandlers() err: wrong output:\n%s", eventHandlers, output)
 }
+
+func receive(t *testing.T, events <-chan Event) []string {
+	t.Helper()
+
+	names := []string{}
+
+	for {
+		select {
+		case e := <-events:
+			names = append(names, e.Name)
+		default:
+			return names
+		}
+	}
+}
+
+func TestPublishDropOldest(t *testing.T) {
+	b := &Broker{}
+	events, unsubscribe := b.Subscribe("drop-oldest/*", WithBufferSize(2))
+	defer unsubscribe()
+
+	b.Publish(Event{Name: "drop-oldest/1"})
+	b.Publish(Event{Name: "drop-oldest/2"})
+	b.Publish(Event{Name: "drop-oldest/3"})
+
+	test.Compare(t, "Publish() unexpected events:\n%s", []string{"drop-oldest/2", "drop-oldest/3"}, receive(t, events))
+	test.Equal(t, "Publish() want %v dropped events, got: %v", 1.0, testutil.ToFloat64(metrics.dropped.WithLabelValues("drop-oldest/*")))
+	test.Equal(t, "Publish() want %v delivered events, got: %v", 3.0, testutil.ToFloat64(metrics.delivered.WithLabelValues("drop-oldest/*")))
+}
+
+func TestPublishDropNewest(t *testing.T) {
+	b := &Broker{}
+	events, unsubscribe := b.Subscribe("drop-newest/*", WithBufferSize(2), WithOverflowPolicy(DropNewest))
+	defer unsubscribe()
+
+	b.Publish(Event{Name: "drop-newest/1"})
+	b.Publish(Event{Name: "drop-newest/2"})
+	b.Publish(Event{Name: "drop-newest/3"})
+
+	test.Compare(t, "Publish() unexpected events:\n%s", []string{"drop-newest/1", "drop-newest/2"}, receive(t, events))
+	test.Equal(t, "Publish() want %v dropped events, got: %v", 1.0, testutil.ToFloat64(metrics.dropped.WithLabelValues("drop-newest/*")))
+}
+
+func TestPublishBlockTimesOut(t *testing.T) {
+	b := &Broker{}
+	_, unsubscribe := b.Subscribe("block", WithBufferSize(0), WithOverflowPolicy(Block), WithBlockTimeout(10*time.Millisecond))
+	defer unsubscribe()
+
+	start := time.Now()
+	b.Publish(Event{Name: "block"})
+
+	test.Assert(t, "Publish() want to wait for the block timeout, waited: %s", time.Since(start) >= 10*time.Millisecond, time.Since(start))
+	test.Equal(t, "Publish() want %v dropped events, got: %v", 1.0, testutil.ToFloat64(metrics.dropped.WithLabelValues("block")))
+}
+
+func TestUnsubscribeDuringBlockedPublish(t *testing.T) {
+	b := &Broker{}
+	_, unsubscribe := b.Subscribe("unsubscribe", WithBufferSize(0), WithOverflowPolicy(Block), WithBlockTimeout(time.Minute))
+
+	published := make(chan struct{})
+
+	go func() {
+		b.Publish(Event{Name: "unsubscribe"})
+		close(published)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	unsubscribe()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish() is still blocked after unsubscribing")
+	}
+
+	// unsubscribing twice is a no-op
+	unsubscribe()
+}
+
+func TestClose(t *testing.T) {
+	b := &Broker{}
+	events, _ := b.Subscribe("close")
+
+	b.Close()
+
+	_, ok := <-events
+	test.Assert(t, "Close() want closed subscription channels", !ok)
+
+	b.Publish(Event{Name: "close"})
+
+	late, _ := b.Subscribe("close")
+	_, ok = <-late
+	test.Assert(t, "Subscribe() after Close() want a closed channel", !ok)
+}
@@ -1,10 +1,14 @@
 package events
 
 // Event contains the actual event (Body) and meta information
-// like the name of the Event.
+// like the name of the Event. The ID is set by the Broker when
+// the event is published, it increases with every event.
+// Events that are replayed keep their ID and have `Replay` set.
 type Event struct {
-	Name string      `json:"name"`
-	Body interface{} `json:"body"`
+	ID     uint64      `json:"id"`
+	Name   string      `json:"name"`
+	Body   interface{} `json:"body"`
+	Replay bool        `json:"replay,omitempty"`
 }
 
 // Unsubscribe allows unsubscribing of an Event.
@@ -17,7 +21,7 @@ type Publisher interface {
 
 // Subscriber can subscribe to events.
 type Subscriber interface {
-	Subscribe(eventName string) (<-chan Event, Unsubscribe)
+	Subscribe(eventName string, opts ...SubscribeOption) (<-chan Event, Unsubscribe)
 }
 
 // PublisherSubscriber implements both Publisher and Subscriber.
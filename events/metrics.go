@@ -0,0 +1,25 @@
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metrics = struct {
+	delivered   *prometheus.CounterVec
+	dropped     *prometheus.CounterVec
+	storeErrors prometheus.Counter
+}{
+	delivered: promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "api_events_delivered",
+		Help: "The total number of events delivered to subscribers",
+	}, []string{"subscription"}),
+	dropped: promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "api_events_dropped",
+		Help: "The total number of events dropped because a subscriber was too slow",
+	}, []string{"subscription"}),
+	storeErrors: promauto.NewCounter(prometheus.CounterOpts{
+		Name: "api_events_store_errors",
+		Help: "The total number of events that could not be persisted",
+	}),
+}
@@ -0,0 +1,212 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrNoStore is returned by operations that need a broker with a `Store`.
+var ErrNoStore = errors.New("the broker has no event store")
+
+// storePageSize is the number of events that are loaded from the store at once.
+const storePageSize = 500
+
+// Store persists published events, the bodies of loaded
+// events are json encoded (`json.RawMessage`).
+type Store interface {
+	// Append persists an event and returns its ID, which must be greater
+	// than the IDs of all previously appended events. It can be called
+	// by several publishers at once.
+	Append(event Event) (uint64, error)
+	// Since loads up to `limit` events with an ID greater than `id`, oldest first.
+	Since(id uint64, limit int) ([]Event, error)
+	// From loads up to `limit` events that were published at or after `from`
+	// and have an ID greater than `id`, oldest first.
+	From(from time.Time, id uint64, limit int) ([]Event, error)
+}
+
+// since loads the next page of events with an ID greater than `id` that
+// were published at or after `from` and match `eventName`. `next` is the
+// ID to continue from and `more` is false on the last page.
+func (b *Broker) since(eventName string, from time.Time, id uint64) (events []Event, next uint64, more bool, err error) {
+	if b.Store == nil {
+		return b.historySince(eventName, id), id, false, nil
+	}
+
+	var page []Event
+
+	if from.IsZero() {
+		page, err = b.Store.Since(id, storePageSize)
+	} else {
+		page, err = b.Store.From(from, id, storePageSize)
+	}
+
+	if err != nil {
+		return nil, id, false, errors.Wrap(err, "load stored events")
+	}
+
+	events = []Event{}
+
+	for _, event := range page {
+		if Matches(eventName, event.Name) {
+			events = append(events, event)
+		}
+
+		id = event.ID
+	}
+
+	return events, id, len(page) == storePageSize, nil
+}
+
+// Resume subscribes to `eventName` and first sends the events that were
+// published after the event with the ID `id`, which allows subscribers to
+// catch up on the events they missed. The missed events are loaded page by
+// page while they are sent, if `MaxResumeAge` is set the events that were
+// published earlier than that are skipped. The subscription is only made
+// once the missed events are sent, so a long catch-up can't overflow its
+// buffer, the events published in between are loaded again before
+// switching to the live events.
+func (b *Broker) Resume(eventName string, id uint64, opts ...SubscribeOption) (<-chan Event, Unsubscribe, error) {
+	var from time.Time
+
+	if b.MaxResumeAge > 0 {
+		from = time.Now().Add(-b.MaxResumeAge)
+	}
+
+	missed, next, more, err := b.since(eventName, from, id)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan Event)
+	done := make(chan struct{})
+
+	// catchUp sends the missed events and loads the following ones
+	// until there are none left, `next` is the last ID it covered.
+	catchUp := func() bool {
+		for {
+			for _, event := range missed {
+				select {
+				case events <- event:
+				case <-done:
+					return false
+				}
+
+				if event.ID > next {
+					next = event.ID
+				}
+			}
+
+			if len(missed) == 0 && !more {
+				return true
+			}
+
+			if missed, next, more, err = b.since(eventName, from, next); err != nil {
+				zap.S().Warnf("could not resume events %s: %+v", eventName, err)
+				return false
+			}
+		}
+	}
+
+	go func() {
+		defer close(events)
+
+		if !catchUp() {
+			return
+		}
+
+		live, unsubscribe := b.Subscribe(eventName, opts...)
+		defer unsubscribe()
+
+		// load the events that were published before subscribing
+		if missed, next, more, err = b.since(eventName, from, next); err != nil {
+			zap.S().Warnf("could not resume events %s: %+v", eventName, err)
+			return
+		}
+
+		if !catchUp() {
+			return
+		}
+
+		for {
+			var event Event
+			var ok bool
+
+			select {
+			case event, ok = <-live:
+				if !ok {
+					return
+				}
+			case <-done:
+				return
+			}
+
+			// skip the events that were already sent, events
+			// that couldn't be stored have no ID and are never sent twice
+			if event.ID != 0 && event.ID <= next && !event.Replay {
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	once := sync.Once{}
+
+	return events, func() {
+		once.Do(func() {
+			close(done)
+		})
+	}, nil
+}
+
+// Replay publishes the stored events that match `eventName` and were
+// published at or after `from` again, the events keep their ID and are
+// not stored again. It returns the number of replayed events.
+func (b *Broker) Replay(eventName string, from time.Time) (int, error) {
+	if b.Store == nil {
+		return 0, ErrNoStore
+	}
+
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	if b.closed {
+		return 0, nil
+	}
+
+	var id uint64
+	count := 0
+
+	for {
+		page, err := b.Store.From(from, id, storePageSize)
+
+		if err != nil {
+			return count, errors.Wrap(err, "load stored events")
+		}
+
+		for _, event := range page {
+			id = event.ID
+
+			if !Matches(eventName, event.Name) {
+				continue
+			}
+
+			event.Replay = true
+			b.deliver(event)
+			count++
+		}
+
+		if len(page) < storePageSize {
+			return count, nil
+		}
+	}
+}
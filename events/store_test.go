@@ -0,0 +1,241 @@
+package events
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+)
+
+// memoryStore is a `Store` that keeps the events in memory.
+type memoryStore struct {
+	mutex  sync.Mutex
+	events []Event
+	times  []time.Time // when the events were appended
+}
+
+func (s *memoryStore) Append(event Event) (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	event.ID = uint64(len(s.events) + 1)
+	s.events = append(s.events, event)
+	s.times = append(s.times, time.Now())
+
+	return event.ID, nil
+}
+
+func (s *memoryStore) Since(id uint64, limit int) ([]Event, error) {
+	return s.From(time.Time{}, id, limit)
+}
+
+func (s *memoryStore) From(from time.Time, id uint64, limit int) ([]Event, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	events := []Event{}
+
+	for i, e := range s.events {
+		if e.ID > id && !s.times[i].Before(from) && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
+func names(events []Event) []string {
+	names := []string{}
+
+	for _, e := range events {
+		names = append(names, e.Name)
+	}
+
+	return names
+}
+
+func TestResume(t *testing.T) {
+	b := &Broker{Store: &memoryStore{}}
+
+	b.Publish(Event{Name: "resume/1"})
+	b.Publish(Event{Name: "other/2"})
+	b.Publish(Event{Name: "resume/3"})
+
+	events, unsubscribe, err := b.Resume("resume/*", 1)
+	test.Check(t, "Resume() err: %v", err)
+	defer unsubscribe()
+
+	b.Publish(Event{Name: "resume/4"})
+
+	received := []Event{<-events, <-events}
+
+	test.Compare(t, "Resume() unexpected events:\n%s", []string{"resume/3", "resume/4"}, names(received))
+	test.Equal(t, "Resume() want the store's id %d, got %d", uint64(4), received[1].ID)
+}
+
+func TestResumeLoadsPages(t *testing.T) {
+	b := &Broker{Store: &memoryStore{}}
+
+	total := storePageSize*2 + 1
+
+	for i := 0; i < total; i++ {
+		b.Publish(Event{Name: "resume/page"})
+	}
+
+	events, unsubscribe, err := b.Resume("resume/*", 0)
+	test.Check(t, "Resume() err: %v", err)
+	defer unsubscribe()
+
+	for i := 1; i <= total; i++ {
+		event := <-events
+		test.Equal(t, "Resume() want event %d, got %d", uint64(i), event.ID)
+	}
+}
+
+func TestResumeWithSmallBuffer(t *testing.T) {
+	b := &Broker{Store: &memoryStore{}}
+
+	total := storePageSize + 1
+
+	for i := 0; i < total; i++ {
+		b.Publish(Event{Name: "resume/page"})
+	}
+
+	events, unsubscribe, err := b.Resume("resume/*", 0, WithBufferSize(1))
+	test.Check(t, "Resume() err: %v", err)
+	defer unsubscribe()
+
+	// publish while the missed events are still being sent
+	for i := 0; i < 10; i++ {
+		b.Publish(Event{Name: "resume/live"})
+	}
+
+	for i := 1; i <= total+10; i++ {
+		event := <-events
+		test.Equal(t, "Resume() want event %d, got %d", uint64(i), event.ID)
+	}
+}
+
+// failingStore is a `Store` that can't append events.
+type failingStore struct {
+	memoryStore
+}
+
+func (s *failingStore) Append(event Event) (uint64, error) {
+	return 0, errors.New("store is unavailable")
+}
+
+func TestResumeSendsEventsWithoutID(t *testing.T) {
+	b := &Broker{Store: &failingStore{}}
+
+	events, unsubscribe, err := b.Resume("resume/*", 5)
+	test.Check(t, "Resume() err: %v", err)
+	defer unsubscribe()
+
+	// wait until Resume switched to the live events
+	deadline := time.Now().Add(time.Second)
+
+	for b.subscriberCount("resume/*") == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	b.Publish(Event{Name: "resume/unstored"})
+
+	select {
+	case event := <-events:
+		test.Equal(t, "Resume() want event %q, got %q", "resume/unstored", event.Name)
+	case <-time.After(time.Second):
+		t.Fatal("Resume() want the event without an id to be sent")
+	}
+}
+
+// slowStore is a `Store` whose appends block until `release` is closed.
+type slowStore struct {
+	memoryStore
+	release chan struct{}
+}
+
+func (s *slowStore) Append(event Event) (uint64, error) {
+	if event.Name == "slow/append" {
+		<-s.release
+	}
+
+	return s.memoryStore.Append(event)
+}
+
+func TestPublishDuringSlowAppend(t *testing.T) {
+	store := &slowStore{release: make(chan struct{})}
+	b := &Broker{Store: store}
+
+	defer close(store.release)
+
+	go b.Publish(Event{Name: "slow/append"})
+
+	events, unsubscribe := b.Subscribe("fast/*")
+	defer unsubscribe()
+
+	go b.Publish(Event{Name: "fast/append"})
+
+	select {
+	case event := <-events:
+		test.Equal(t, "Publish() want event %q, got %q", "fast/append", event.Name)
+	case <-time.After(time.Second):
+		t.Fatal("Publish() is blocked by a slow append")
+	}
+}
+
+func TestResumeMaxAge(t *testing.T) {
+	store := &memoryStore{}
+	b := &Broker{Store: store, MaxResumeAge: time.Hour}
+
+	b.Publish(Event{Name: "resume/old"})
+	b.Publish(Event{Name: "resume/new"})
+
+	store.times[0] = time.Now().Add(-2 * time.Hour)
+
+	events, unsubscribe, err := b.Resume("resume/*", 0)
+	test.Check(t, "Resume() err: %v", err)
+	defer unsubscribe()
+
+	test.Equal(t, "Resume() want event %q, got %q", "resume/new", (<-events).Name)
+}
+
+func TestReplay(t *testing.T) {
+	b := &Broker{Store: &memoryStore{}}
+
+	b.Publish(Event{Name: "replay/1"})
+	b.Publish(Event{Name: "replay/2"})
+
+	events, unsubscribe := b.Subscribe("replay/*")
+	defer unsubscribe()
+
+	count, err := b.Replay("replay/*", time.Time{})
+	test.Check(t, "Replay() err: %v", err)
+	test.Equal(t, "Replay() want %d replayed events, got %d", 2, count)
+
+	received := []Event{<-events, <-events}
+
+	test.Compare(t, "Replay() unexpected events:\n%s", []string{"replay/1", "replay/2"}, names(received))
+	test.Assert(t, "Replay() want .Replay to be set", received[0].Replay && received[1].Replay)
+}
+
+func TestReplayWithoutStore(t *testing.T) {
+	b := &Broker{}
+
+	_, err := b.Replay("replay/*", time.Time{})
+
+	test.Assert(t, "Replay() want ErrNoStore, got: %v", err == ErrNoStore, err)
+}
+
+// subscriberCount returns the number of subscriptions to `eventName`.
+func (b *Broker) subscriberCount(eventName string) int {
+	s, ok := b.subscribers.Load(eventName)
+
+	if !ok {
+		return 0
+	}
+
+	return len(s.(*subscriptions).All())
+}
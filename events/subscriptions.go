@@ -1,26 +1,187 @@
 package events
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
+
+// OverflowPolicy decides what happens to an event that is published while
+// the buffer of a subscription is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest removes the oldest buffered event to make room for the new one.
+	DropOldest OverflowPolicy = iota
+	// DropNewest drops the event that is published.
+	DropNewest
+	// Block waits until there is room in the buffer, or drops the
+	// event if the block timeout is exceeded.
+	Block
+)
+
+const (
+	defaultBufferSize   = 64
+	defaultBlockTimeout = time.Second
+)
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscription)
+
+// WithBufferSize sets the number of events that are buffered for a
+// subscriber before the overflow policy is applied.
+func WithBufferSize(size int) SubscribeOption {
+	return func(s *subscription) {
+		s.bufferSize = size
+	}
+}
+
+// WithOverflowPolicy sets the policy that is applied when the buffer is full.
+func WithOverflowPolicy(policy OverflowPolicy) SubscribeOption {
+	return func(s *subscription) {
+		s.policy = policy
+	}
+}
+
+// WithBlockTimeout sets how long `Block` waits for room in the buffer.
+func WithBlockTimeout(timeout time.Duration) SubscribeOption {
+	return func(s *subscription) {
+		s.blockTimeout = timeout
+	}
+}
+
+type subscription struct {
+	name         string
+	bufferSize   int
+	policy       OverflowPolicy
+	blockTimeout time.Duration
+
+	mutex  sync.Mutex // serializes deliveries and closing
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+	closed bool
+}
+
+func newSubscription(name string, opts ...SubscribeOption) *subscription {
+	s := &subscription{
+		name:         name,
+		bufferSize:   defaultBufferSize,
+		policy:       DropOldest,
+		blockTimeout: defaultBlockTimeout,
+		done:         make(chan struct{}),
+	}
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	if s.bufferSize < 0 {
+		s.bufferSize = 0
+	}
+
+	s.events = make(chan Event, s.bufferSize)
+
+	return s
+}
+
+// deliver sends the event to the subscriber according to the overflow policy,
+// it never blocks longer than the block timeout.
+func (s *subscription) deliver(event Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.events <- event:
+		metrics.delivered.WithLabelValues(s.name).Inc()
+		return
+	default:
+	}
+
+	switch s.policy {
+	case DropOldest:
+		for {
+			select {
+			case s.events <- event:
+				metrics.delivered.WithLabelValues(s.name).Inc()
+				return
+			default:
+			}
+
+			select {
+			case <-s.events:
+				metrics.dropped.WithLabelValues(s.name).Inc()
+			default:
+			}
+
+			// an unbuffered subscription has nothing to drop
+			if s.bufferSize == 0 {
+				metrics.dropped.WithLabelValues(s.name).Inc()
+				return
+			}
+		}
+	case Block:
+		timer := time.NewTimer(s.blockTimeout)
+		defer timer.Stop()
+
+		select {
+		case s.events <- event:
+			metrics.delivered.WithLabelValues(s.name).Inc()
+		case <-timer.C:
+			metrics.dropped.WithLabelValues(s.name).Inc()
+		case <-s.done:
+		}
+	default:
+		metrics.dropped.WithLabelValues(s.name).Inc()
+	}
+}
+
+// close closes the event channel, a blocked delivery is
+// cancelled first so closing never has to wait for it.
+func (s *subscription) close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.closed = true
+		close(s.events)
+	})
+}
 
 type subscriptions struct {
 	mutex     sync.Mutex
-	listeners []chan<- Event
+	listeners []*subscription
 }
 
-func (s *subscriptions) Add(listener chan<- Event) {
+func (s *subscriptions) Add(listener *subscription) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	s.listeners = append(s.listeners, listener)
 }
 
-func (s *subscriptions) Remove(listener chan<- Event) {
+func (s *subscriptions) Remove(listener *subscription) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	for i, l := range s.listeners {
 		if l == listener {
 			s.listeners = append(s.listeners[:i], s.listeners[i+1:]...)
+			break
 		}
 	}
 }
+
+// All returns a copy of the listeners so events can be
+// delivered without holding the lock.
+func (s *subscriptions) All() []*subscription {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*subscription{}, s.listeners...)
+}
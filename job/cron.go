@@ -0,0 +1,304 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Cron parses a standard cron expression with 5 fields (minute, hour, day of
+// month, month, day of week) or 6 fields (with a leading second). Fields may
+// contain `*`, `?`, lists (`1,15`), ranges (`1-5`), steps (`*/10`, `8-18/2`)
+// and the names of months and weekdays (`JAN`, `MON-FRI`). The descriptors
+// `@yearly`, `@monthly`, `@weekly`, `@daily` and `@hourly` are supported as
+// well. The times are evaluated in `location`, or the local time if it is nil.
+//
+// Like in cron a job runs on a day if either the day of month or the day of
+// week matches, unless one of them is `*`.
+func Cron(expression string, location *time.Location) (Schedule, error) {
+	if location == nil {
+		location = time.Local
+	}
+
+	spec := strings.TrimSpace(expression)
+
+	if descriptor, ok := cronDescriptors[spec]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, errors.Errorf("cron expression %q must have 5 or 6 fields", expression)
+	}
+
+	s := &cronSchedule{expression: expression, location: location}
+
+	bounds := []cronBounds{seconds, minutes, hours, daysOfMonth, months, daysOfWeek}
+	sets := []*uint64{&s.second, &s.minute, &s.hour, &s.dayOfMonth, &s.month, &s.dayOfWeek}
+
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i])
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "cron expression %q", expression)
+		}
+
+		*sets[i] = set
+	}
+
+	s.anyDayOfMonth = fields[3] == "*" || fields[3] == "?"
+	s.anyDayOfWeek = fields[5] == "*" || fields[5] == "?"
+
+	// 7 is an alias for sunday
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+
+	return s, nil
+}
+
+// MustCron is like Cron but panics if the expression is invalid.
+func MustCron(expression string, location *time.Location) Schedule {
+	s, err := Cron(expression, location)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronBounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	seconds     = cronBounds{min: 0, max: 59}
+	minutes     = cronBounds{min: 0, max: 59}
+	hours       = cronBounds{min: 0, max: 23}
+	daysOfMonth = cronBounds{min: 1, max: 31}
+	months      = cronBounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	daysOfWeek = cronBounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// parseCronField returns the set of values of a field as a bitset.
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var set uint64
+
+	for _, item := range strings.Split(field, ",") {
+		rangeAndStep := strings.SplitN(item, "/", 2)
+		low, high := bounds.min, bounds.max
+		step := 1
+
+		switch r := rangeAndStep[0]; {
+		case r == "*" || r == "?":
+		case strings.Contains(r, "-"):
+			parts := strings.SplitN(r, "-", 2)
+
+			var err error
+
+			if low, err = bounds.value(parts[0]); err != nil {
+				return 0, err
+			}
+
+			if high, err = bounds.value(parts[1]); err != nil {
+				return 0, err
+			}
+		default:
+			value, err := bounds.value(r)
+
+			if err != nil {
+				return 0, err
+			}
+
+			low = value
+
+			// `5/10` means every 10th value starting at 5
+			if len(rangeAndStep) == 1 {
+				high = value
+			}
+		}
+
+		if len(rangeAndStep) == 2 {
+			var err error
+
+			if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step < 1 {
+				return 0, errors.Errorf("invalid step in %q", item)
+			}
+		}
+
+		if low > high {
+			return 0, errors.Errorf("invalid range %q", item)
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func (b cronBounds) value(s string) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+
+	if err != nil {
+		return 0, errors.Errorf("invalid value %q", s)
+	}
+
+	if v < b.min || v > b.max {
+		return 0, errors.Errorf("value %d is not between %d and %d", v, b.min, b.max)
+	}
+
+	return v, nil
+}
+
+type cronSchedule struct {
+	expression string
+	location   *time.Location
+
+	second, minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// cronSearchYears limits the search for the next run, e.g. for `0 0 30 2 *`.
+const cronSearchYears = 5
+
+// Next returns the first time after `t` that matches the expression, or the
+// zero time if there is none. Times that don't exist because of a daylight
+// saving time switch are skipped.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	original := t.Location()
+
+	// start at the next full second
+	t = t.In(s.location).Add(time.Second - time.Duration(t.Nanosecond()))
+	limit := t.Year() + cronSearchYears
+
+	// as soon as a field is incremented the lower fields start at their minimum
+	truncated := false
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+
+	for !s.matches(s.month, int(t.Month())) {
+		if !truncated {
+			truncated = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.location)
+		}
+
+		t = t.AddDate(0, 1, 0)
+
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !truncated {
+			truncated = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+		}
+
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for !s.matches(s.hour, t.Hour()) {
+		if !truncated {
+			truncated = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.location)
+		}
+
+		t = t.Add(time.Hour)
+
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+
+	for !s.matches(s.minute, t.Minute()) {
+		if !truncated {
+			truncated = true
+			t = t.Truncate(time.Minute)
+		}
+
+		t = t.Add(time.Minute)
+
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	for !s.matches(s.second, t.Second()) {
+		if !truncated {
+			truncated = true
+			t = t.Truncate(time.Second)
+		}
+
+		t = t.Add(time.Second)
+
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+
+	return t.In(original)
+}
+
+func (s *cronSchedule) matches(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dayOfMonth := s.matches(s.dayOfMonth, t.Day())
+	dayOfWeek := s.matches(s.dayOfWeek, int(t.Weekday()))
+
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
+
+func (s *cronSchedule) String() string {
+	return fmt.Sprintf("%s (%s)", s.expression, s.location)
+}
+
+// MarshalText describes the schedule in the job report.
+func (s *cronSchedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,160 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+)
+
+func viennaLocation(t *testing.T) *time.Location {
+	t.Helper()
+
+	location, err := time.LoadLocation("Europe/Vienna")
+
+	if err != nil {
+		t.Skipf("timezone Europe/Vienna is not available: %v", err)
+	}
+
+	return location
+}
+
+func TestCronInvalidExpressions(t *testing.T) {
+	expressions := []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * FOO *",
+		"@every",
+	}
+
+	for _, expression := range expressions {
+		if _, err := Cron(expression, time.UTC); err == nil {
+			t.Errorf("Cron(%q) expected an error", expression)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	vienna := viennaLocation(t)
+
+	tests := []struct {
+		name       string
+		expression string
+		from       time.Time
+		want       time.Time
+	}{
+		{
+			name:       "daily later on the same day",
+			expression: "0 4 * * *",
+			from:       time.Date(2020, 6, 1, 3, 59, 59, 0, vienna),
+			want:       time.Date(2020, 6, 1, 4, 0, 0, 0, vienna),
+		},
+		{
+			name:       "daily on the next day",
+			expression: "0 4 * * *",
+			from:       time.Date(2020, 6, 1, 4, 0, 0, 0, vienna),
+			want:       time.Date(2020, 6, 2, 4, 0, 0, 0, vienna),
+		},
+		{
+			name:       "with seconds",
+			expression: "30 */15 * * * *",
+			from:       time.Date(2020, 6, 1, 10, 15, 30, 0, vienna),
+			want:       time.Date(2020, 6, 1, 10, 30, 30, 0, vienna),
+		},
+		{
+			name:       "weekdays",
+			expression: "0 8-18/2 * * MON-FRI",
+			from:       time.Date(2020, 6, 5, 18, 0, 0, 0, vienna), // friday
+			want:       time.Date(2020, 6, 8, 8, 0, 0, 0, vienna),
+		},
+		{
+			name:       "sunday as 7",
+			expression: "0 0 * * 7",
+			from:       time.Date(2020, 6, 1, 0, 0, 0, 0, vienna), // monday
+			want:       time.Date(2020, 6, 7, 0, 0, 0, 0, vienna),
+		},
+		{
+			name:       "day of month or day of week",
+			expression: "0 0 15 * MON",
+			from:       time.Date(2020, 6, 9, 0, 0, 0, 0, vienna), // tuesday
+			want:       time.Date(2020, 6, 15, 0, 0, 0, 0, vienna),
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 FEB *",
+			from:       time.Date(2020, 3, 1, 0, 0, 0, 0, vienna),
+			want:       time.Date(2024, 2, 29, 0, 0, 0, 0, vienna),
+		},
+		{
+			name:       "descriptor",
+			expression: "@monthly",
+			from:       time.Date(2020, 12, 15, 0, 0, 0, 0, vienna),
+			want:       time.Date(2021, 1, 1, 0, 0, 0, 0, vienna),
+		},
+		{
+			name:       "skips the hour that doesn't exist",
+			expression: "30 2 * * *",
+			from:       time.Date(2020, 3, 29, 0, 0, 0, 0, vienna), // clocks jump from 2:00 to 3:00
+			want:       time.Date(2020, 3, 30, 2, 30, 0, 0, vienna),
+		},
+		{
+			name:       "other timezone",
+			expression: "0 4 * * *",
+			from:       time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), // 2:00 in vienna
+			want:       time.Date(2020, 6, 1, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Cron(tt.expression, vienna)
+			test.Check(t, "Cron() failed: %v", err)
+
+			got := schedule.Next(tt.from)
+
+			test.Assert(t, "Next() want %v, got %v", got.Equal(tt.want), tt.want, got)
+		})
+	}
+}
+
+func TestCronWithoutNextRun(t *testing.T) {
+	schedule := MustCron("0 0 30 2 *", time.UTC)
+
+	got := schedule.Next(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	test.Assert(t, "Next() want zero time, got %v", got.IsZero(), got)
+}
+
+func TestEvery(t *testing.T) {
+	from := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	got := Every(5 * time.Minute).Next(from)
+
+	test.Assert(t, "Next() want %v, got %v", got.Equal(from.Add(5*time.Minute)), from.Add(5*time.Minute), got)
+}
+
+func TestJobNextRun(t *testing.T) {
+	now := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	j := &Job{Schedule: MustCron("0 12 * * *", time.UTC), Delay: time.Minute}
+	got := j.nextRun(now)
+	test.Assert(t, "first nextRun() want %v, got %v", got.Equal(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)), "12:00", got)
+
+	j = &Job{Interval: time.Hour, Delay: time.Minute}
+	got = j.nextRun(now)
+	test.Assert(t, "first nextRun() want %v, got %v", got.Equal(now.Add(time.Minute)), now.Add(time.Minute), got)
+
+	// the last run started 2h ago and took longer than the interval
+	j.Execution.Runs = 1
+	j.Execution.start = now.Add(-2 * time.Hour)
+	got = j.nextRun(now)
+	test.Assert(t, "nextRun() want %v, got %v", got.Equal(now), now, got)
+}
@@ -10,22 +10,46 @@ const (
 	SignalStop = 0
 	// SignalStart signals a job to wake up and run
 	SignalStart = 1
+	// SignalPause signals a job to skip its runs until it is resumed
+	SignalPause = 2
+	// SignalResume signals a paused job to continue with its schedule
+	SignalResume = 3
 )
 
 // Execution represents a running job
 type Execution struct {
 	LastRun      time.Time     `json:"lastRun"`
 	LastDuration time.Duration `json:"lastDuration"`
+	NextRun      time.Time     `json:"nextRun"`
+	Retrying     bool          `json:"retrying"` // set if the next run is a retry of a failed run
 
-	Errors []error `json:"errors"`
-	Runs   uint    `json:"runs"`
-	State  State   `json:"state"`
+	Errors  []error `json:"errors"`
+	Runs    uint    `json:"runs"`
+	Skipped uint    `json:"skipped"` // runs that were skipped because the job ran on another instance
+	State   State   `json:"state"`
 
 	start time.Time
 	end   time.Time
 	sleep time.Duration
 
+	// when a failed run is retried, or the errored job recovered
+	retryAt  time.Time
+	cooldown bool
+
+	// closed when a run that timed out but didn't return yet has returned
+	abandoned chan struct{}
+	// set while a run waits for the abandoned run to return
+	waiting bool
+
 	lock sync.Mutex
 
+	// requested by the Manager's Trigger, Pause and Stop methods
+	triggered     bool
+	paused        bool
+	stopRequested bool
+
+	// set while the job has a schedule go routine
+	scheduled bool
+
 	signal chan int
 }
@@ -1,18 +1,101 @@
 package job
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
-// Job is the definition of a job which is run the Manager in defined intervals.
+// Job is the definition of a job which is run the Manager according to its schedule.
 type Job struct {
 	MaxRuns     uint          `json:"maxRuns"`     // limit the # of runs, no limit if 0
 	Name        string        `json:"name"`        // name of the job, useful in logs
 	MaxFailures uint          `json:"maxFailures"` // max # of consecutive failures, retries endlessly if 0
 	Interval    time.Duration `json:"interval"`    // attempts to call the job every interval, if the job takes longer than the interval it will be restarted immediately after finishing
 	Delay       time.Duration `json:"delay"`       // delays first job start
+	Schedule    Schedule      `json:"schedule"`    // decides when the job runs, replaces `Interval` if set
+	Retry       *RetryPolicy  `json:"retry"`       // retries failed runs before the next scheduled run, if set
+	Timeout     time.Duration `json:"timeout"`     // cancels the context of a run that takes longer, no timeout if 0
 
 	Execution Execution `json:"execution"`
 
-	Do func() error `json:"-"` // when started the job calls the do function
+	Do func(ctx context.Context) error `json:"-"` // when started the job calls the do function, `ctx` is cancelled on shutdown
+}
+
+// schedule returns the schedule of the job, nil if it runs continuously.
+func (j *Job) schedule() Schedule {
+	if j.Schedule != nil {
+		return j.Schedule
+	}
+
+	if j.Interval > 0 {
+		return Every(j.Interval)
+	}
+
+	return nil
+}
+
+// nextRun returns when the job runs next. The first run is after `Delay` and
+// jobs with an `Interval` run immediately, if a run takes longer than the
+// time to the next scheduled run the job is restarted immediately.
+func (j *Job) nextRun(now time.Time) time.Time {
+	if j.Execution.Runs == 0 && j.Execution.Skipped == 0 {
+		start := now.Add(j.Delay)
+
+		if j.Schedule == nil {
+			return start
+		}
+
+		return j.Schedule.Next(start)
+	}
+
+	schedule := j.schedule()
+	next := now
+
+	if schedule != nil {
+		next = schedule.Next(j.Execution.start)
+	}
+
+	if retry := j.Execution.retryAt; !retry.IsZero() && (schedule == nil || next.IsZero() || retry.Before(next)) {
+		next = retry
+	}
+
+	if next.Before(now) {
+		return now
+	}
+
+	return next
+}
+
+// snapshot returns a copy of the job and its current execution.
+func (j *Job) snapshot() Job {
+	j.Execution.lock.Lock()
+	defer j.Execution.lock.Unlock()
+
+	return Job{
+		MaxRuns:     j.MaxRuns,
+		Name:        j.Name,
+		MaxFailures: j.MaxFailures,
+		Interval:    j.Interval,
+		Delay:       j.Delay,
+		Schedule:    j.Schedule,
+		Retry:       j.Retry,
+		Timeout:     j.Timeout,
+		Execution: Execution{
+			LastRun:      j.Execution.LastRun,
+			LastDuration: j.Execution.LastDuration,
+			NextRun:      j.Execution.NextRun,
+			Errors:       append([]error(nil), j.Execution.Errors...),
+			Runs:         j.Execution.Runs,
+			Skipped:      j.Execution.Skipped,
+			State:        j.Execution.State,
+			Retrying:     j.Execution.Retrying,
+			start:        j.Execution.start,
+			end:          j.Execution.end,
+			retryAt:      j.Execution.retryAt,
+			cooldown:     j.Execution.cooldown,
+		},
+		Do: j.Do,
+	}
 }
 
 func (j *Job) hasFailed() bool {
@@ -28,10 +111,6 @@ func (j *Job) canStart() bool {
 	return state == StateStopped || state == StateWaiting || state == StateErrored
 }
 
-func (j *Job) start() {
-	j.Execution.signal <- SignalStart
-}
-
 func (j *Job) canRun() bool {
 	return j.Execution.State == StateWaiting
 }
@@ -45,6 +124,12 @@ func (j *Job) shouldStop() bool {
 	return j.MaxRuns > 0 && j.Execution.Runs >= j.MaxRuns
 }
 
-func (j *Job) stop() {
-
+// wake signals the schedule go routine of the job to check for requests,
+// it never blocks. Pending signals are not lost because the requests are
+// stored in the execution. The execution lock must be held.
+func (j *Job) wake(signal int) {
+	select {
+	case j.Execution.signal <- signal:
+	default:
+	}
 }
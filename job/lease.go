@@ -0,0 +1,105 @@
+package job
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultLeaseTTL is how long a lease is valid if it isn't renewed.
+const defaultLeaseTTL = time.Minute
+
+// Locker grants the lease on a job to an instance, while an instance holds
+// the lease the other instances don't run the job. Leases expire after
+// their `ttl` so the jobs of a crashed instance are run by the others.
+type Locker interface {
+	// Acquire acquires or renews the lease of `holder` on a job, it
+	// returns false if another holder has an unexpired lease on it.
+	Acquire(jobName, holder string, ttl time.Duration) (bool, error)
+	// Release gives up the lease of `holder` on a job.
+	Release(jobName, holder string) error
+}
+
+// instanceName returns a name that identifies this instance as a lease holder.
+func instanceName() string {
+	hostname, err := os.Hostname()
+
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	b := make([]byte, 4)
+	rand.Read(b)
+
+	return fmt.Sprintf("%s-%x", hostname, b)
+}
+
+// acquire acquires the lease on `job` if the manager has a locker, it returns
+// false if another instance holds it. The lease is renewed until `release`
+// is called, if it is lost to another instance or can't be renewed before it
+// expires `lost` is called.
+func (s *Manager) acquire(job *Job, log *zap.SugaredLogger, lost context.CancelFunc) (release func(), ok bool) {
+	if s.Locker == nil {
+		return func() {}, true
+	}
+
+	acquired, err := s.Locker.Acquire(job.Name, s.Instance, s.LeaseTTL)
+
+	if err != nil {
+		log.Warnf("could not acquire the lease on job %q: %v", job.Name, err)
+		return nil, false
+	} else if !acquired {
+		log.Debugf("job %q runs on another instance, skipping", job.Name)
+		return nil, false
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	renewed := time.Now()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.LeaseTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			now := time.Now()
+			acquired, err := s.Locker.Acquire(job.Name, s.Instance, s.LeaseTTL)
+
+			if err != nil && now.Sub(renewed) >= s.LeaseTTL {
+				log.Warnf("could not renew the lease on job %q before it expired, cancelling: %v", job.Name, err)
+				lost()
+				return
+			} else if err != nil {
+				log.Warnf("could not renew the lease on job %q: %v", job.Name, err)
+			} else if !acquired {
+				log.Warnf("job %q lost its lease to another instance, cancelling", job.Name)
+				lost()
+				return
+			} else {
+				renewed = now
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+
+		if err := s.Locker.Release(job.Name, s.Instance); err != nil {
+			log.Warnf("could not release the lease on job %q: %v", job.Name, err)
+		}
+	}, true
+}
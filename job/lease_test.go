@@ -0,0 +1,180 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+)
+
+type memoryLease struct {
+	holder  string
+	expires time.Time
+}
+
+type memoryLocker struct {
+	lock   sync.Mutex
+	leases map[string]memoryLease
+}
+
+func (l *memoryLocker) Acquire(jobName, holder string, ttl time.Duration) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+
+	if lease, ok := l.leases[jobName]; ok && lease.holder != holder && lease.expires.After(now) {
+		return false, nil
+	}
+
+	l.leases[jobName] = memoryLease{holder: holder, expires: now.Add(ttl)}
+
+	return true, nil
+}
+
+func (l *memoryLocker) Release(jobName, holder string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.leases[jobName].holder == holder {
+		delete(l.leases, jobName)
+	}
+
+	return nil
+}
+
+func TestManagersDontRunJobsConcurrently(t *testing.T) {
+	locker := &memoryLocker{leases: map[string]memoryLease{}}
+
+	var running, maxRunning, runs int32
+
+	do := func(ctx context.Context) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+
+		atomic.AddInt32(&runs, 1)
+		time.Sleep(30 * time.Millisecond)
+
+		return nil
+	}
+
+	managers := []*Manager{NewManager(), NewManager()}
+
+	for _, manager := range managers {
+		manager.Locker = locker
+
+		err := manager.Start(Job{Name: "Test", Interval: 10 * time.Millisecond, Do: do})
+		test.Check(t, "manager.Start() failed: %v", err)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	skipped := uint(0)
+
+	for _, manager := range managers {
+		test.Check(t, "manager.Shutdown() failed: %v", manager.Shutdown(context.Background()))
+
+		j, _ := manager.Job("Test")
+		skipped += j.Execution.Skipped
+	}
+
+	test.Equal(t, "expected at most %d concurrent run, got %d", int32(1), atomic.LoadInt32(&maxRunning))
+	test.Assert(t, "expected the job to run, got %d runs", atomic.LoadInt32(&runs) > 0, atomic.LoadInt32(&runs))
+	test.Assert(t, "expected runs to be skipped", skipped > 0)
+}
+
+func TestManagerTakesOverExpiredLeases(t *testing.T) {
+	locker := &memoryLocker{leases: map[string]memoryLease{}}
+
+	// an instance that crashed while it held the lease
+	locker.Acquire("Test", "crashed", 100*time.Millisecond)
+
+	manager := NewManager()
+	manager.Locker = locker
+	manager.LeaseTTL = 50 * time.Millisecond
+
+	ran := make(chan time.Time, 1)
+	start := time.Now()
+
+	err := manager.Start(Job{
+		Name:    "Test",
+		MaxRuns: 1,
+		Do: func(ctx context.Context) error {
+			ran <- time.Now()
+			return nil
+		},
+	})
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	select {
+	case at := <-ran:
+		test.Assert(t, "expected the job to run after the lease expired, ran after %s", at.Sub(start) >= 100*time.Millisecond, at.Sub(start))
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the job to run after the lease expired")
+	}
+
+	j, _ := manager.Job("Test")
+	test.Assert(t, "expected skipped runs, got %d", j.Execution.Skipped > 0, j.Execution.Skipped)
+}
+
+// unreachableLocker grants the first lease and then fails to renew it.
+type unreachableLocker struct {
+	acquired int32
+}
+
+func (l *unreachableLocker) Acquire(jobName, holder string, ttl time.Duration) (bool, error) {
+	if atomic.AddInt32(&l.acquired, 1) == 1 {
+		return true, nil
+	}
+
+	return false, errors.New("database is unreachable")
+}
+
+func (l *unreachableLocker) Release(jobName, holder string) error {
+	return nil
+}
+
+func TestManagerCancelsRunsWhoseLeaseExpires(t *testing.T) {
+	manager := NewManager()
+	manager.Locker = &unreachableLocker{}
+	manager.LeaseTTL = 60 * time.Millisecond
+
+	cancelled := make(chan time.Duration, 1)
+	start := time.Now()
+
+	err := manager.Start(Job{
+		Name:    "Test",
+		MaxRuns: 1,
+		Do: func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				cancelled <- time.Since(start)
+			case <-time.After(5 * time.Second):
+			}
+
+			return ctx.Err()
+		},
+	})
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	select {
+	case after := <-cancelled:
+		test.Assert(t, "expected the run to be cancelled once the lease expired, cancelled after %s", after >= manager.LeaseTTL, after)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the run to be cancelled once the lease expired")
+	}
+
+	test.Check(t, "manager.Shutdown() failed: %v", manager.Shutdown(context.Background()))
+}
@@ -1,112 +1,392 @@
 package job
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrNotFound is returned if a job doesn't exist.
+	ErrNotFound = errors.New("job not found")
+	// ErrInvalidState is returned if a job can't be changed in its current state.
+	ErrInvalidState = errors.New("invalid job state")
+)
+
 // Manager runs jobs in defined intervals
 type Manager struct {
 	waitGroup sync.WaitGroup
 
 	jobs map[string]*Job
 
+	// cancelled on shutdown, the jobs run with this context
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock     sync.Mutex
+	shutdown bool
+
 	log *zap.SugaredLogger
+
+	// Recorder persists the runs of the jobs if it is set.
+	Recorder Recorder
+
+	// Locker makes sure that a job runs on a single instance at a time if
+	// it is set, `Instance` is the name of this instance's leases.
+	Locker   Locker
+	Instance string
+	LeaseTTL time.Duration
 }
 
 // NewManager constructs a new Manager.
 func NewManager() *Manager {
 	log, _ := zap.NewProduction()
+	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Manager{
-		log: log.Sugar(),
+		log:    log.Sugar(),
+		ctx:    ctx,
+		cancel: cancel,
+
+		Instance: instanceName(),
+		LeaseTTL: defaultLeaseTTL,
 	}
 }
 
-// run starts an execution and sets the appropriate state.
-func (s *Manager) run(job *Job) {
+// run starts an execution and sets the appropriate state, it returns false
+// if the job should not be scheduled anymore. The run is recorded with
+// the lines that were logged while it ran.
+func (s *Manager) run(job *Job) bool {
+	log, captured := s.newRunLogger(job)
+
+	ctx, cancel := context.WithCancel(withLogger(s.ctx, log))
+	defer cancel()
+
+	release, ok := s.acquire(job, log, cancel)
+
+	if !ok {
+		s.skip(job)
+		return true
+	}
+
+	defer s.releaseAfterReturn(job, release)
+
 	job.Execution.lock.Lock()
-	job.Execution.State = StateRunning
+
+	if job.hasFailed() {
+		// the job is recovered, e.g. after its cooldown
+		job.Execution.Errors = nil
+	}
+
+	job.Execution.retryAt = time.Time{}
+	job.Execution.cooldown = false
 	job.Execution.lock.Unlock()
-	s.log.Debugf("job %q running", job.Name)
 
 	start := time.Now()
-	err := job.Do()
+
+	// the job keeps its state while it waits for a previous run that
+	// timed out, it is only running once its Do function is called
+	timedOut, err := s.do(ctx, job, func() {
+		job.Execution.lock.Lock()
+		job.Execution.State = StateRunning
+		job.Execution.lock.Unlock()
+		log.Debugf("job %q running", job.Name)
+
+		start = time.Now()
+		metrics.running.started(job.Name, start)
+	})
+	metrics.running.finished(job.Name)
 	end := time.Now()
 
+	if timedOut {
+		metrics.timeouts.WithLabelValues(job.Name).Inc()
+	}
+
+	run := &Run{Job: job.Name, Start: start, End: end, Outcome: OutcomeSucceeded}
+
 	job.Execution.lock.Lock()
-	defer job.Execution.lock.Unlock()
+
+	job.Execution.start = start
+	job.Execution.end = end
 
 	job.Execution.LastDuration = end.Sub(start)
 	job.Execution.LastRun = time.Now()
 	job.Execution.Runs++
 
 	if err != nil {
-		s.log.Warnf("job %q failed: %v", job.Name, err)
+		log.Warnf("job %q failed: %v", job.Name, err)
 		job.Execution.Errors = append(job.Execution.Errors, err)
+
+		run.Outcome = OutcomeFailed
+		run.Error = err.Error()
+
+		if timedOut {
+			run.Outcome = OutcomeTimedOut
+		}
+
+		if job.Retry != nil && job.Retry.Delay > 0 {
+			job.Execution.retryAt = end.Add(job.Retry.delay(len(job.Execution.Errors)))
+		}
 	} else {
-		s.log.Debugf("job %q finished", job.Name)
+		log.Debugf("job %q finished", job.Name)
 		job.Execution.Errors = nil
 	}
 
-	if job.hasFailed() {
-		s.log.Warnf("job %q failed %d times, stopping", job.Name, job.MaxFailures)
+	scheduled := false
+
+	if job.hasFailed() && job.Retry != nil && job.Retry.Cooldown > 0 {
+		log.Warnf("job %q failed %d times, recovering in %s", job.Name, job.MaxFailures, formatDuration(job.Retry.Cooldown))
+		job.Execution.State = StateErrored
+		job.Execution.retryAt = end.Add(job.Retry.Cooldown)
+		job.Execution.cooldown = true
+		scheduled = true
+	} else if job.hasFailed() {
+		log.Warnf("job %q failed %d times, stopping", job.Name, job.MaxFailures)
 		job.Execution.State = StateErrored
 	} else if job.shouldStop() {
-		s.log.Infof("job %q ran %d times, stopping", job.Name, job.Execution.Runs)
+		log.Infof("job %q ran %d times, stopping", job.Name, job.Execution.Runs)
 		job.Execution.State = StateStopped
+	} else if timedOut {
+		job.Execution.State = StateTimedOut
+		scheduled = true
 	} else {
 		job.Execution.State = StateWaiting
+		scheduled = true
+	}
+
+	if !scheduled {
+		job.Execution.scheduled = false
+		job.Execution.NextRun = time.Time{}
+	}
+
+	job.Execution.lock.Unlock()
+
+	run.Log = captured.String()
+	s.record(run)
+
+	return scheduled
+}
+
+// skip skips a run of a job that runs on another instance, the job runs
+// again at its next scheduled run or, if it has no schedule, when the
+// other instance's lease has expired.
+func (s *Manager) skip(job *Job) {
+	job.Execution.lock.Lock()
+	defer job.Execution.lock.Unlock()
+
+	now := time.Now()
+
+	job.Execution.Skipped++
+	job.Execution.start = now
+	job.Execution.retryAt = time.Time{}
+	job.Execution.cooldown = false
+
+	if job.schedule() == nil {
+		job.Execution.retryAt = now.Add(s.LeaseTTL)
+	}
+}
+
+// releaseAfterReturn releases the lease on a job, if the run was abandoned
+// the lease is held until it has returned.
+func (s *Manager) releaseAfterReturn(job *Job, release func()) {
+	job.Execution.lock.Lock()
+	abandoned := job.Execution.abandoned
+	job.Execution.lock.Unlock()
+
+	if abandoned == nil {
+		release()
+		return
+	}
+
+	s.waitGroup.Add(1)
+
+	go func() {
+		defer s.waitGroup.Done()
+
+		<-abandoned
+		release()
+	}()
+}
+
+// do calls the job's Do function with a context that is cancelled after the
+// job's timeout. If the job times out before it has returned the run is
+// abandoned, the next run waits until it has returned so the runs of a job
+// never overlap. `started` is called right before the Do function.
+func (s *Manager) do(ctx context.Context, job *Job, started func()) (timedOut bool, err error) {
+	job.Execution.lock.Lock()
+	abandoned := job.Execution.abandoned
+	job.Execution.waiting = abandoned != nil
+	job.Execution.lock.Unlock()
+
+	if abandoned != nil {
+		s.log.Infof("job %q waits for its previous run that timed out", job.Name)
+
+		select {
+		case <-abandoned:
+		case <-ctx.Done():
+			err = errors.Wrap(ctx.Err(), "the previous run has not returned yet")
+		}
+
+		job.Execution.lock.Lock()
+		job.Execution.waiting = false
+
+		if err == nil {
+			job.Execution.abandoned = nil
+		}
+
+		job.Execution.lock.Unlock()
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	started()
+
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	result := make(chan error, 1)
+	returned := make(chan struct{})
+
+	s.waitGroup.Add(1)
+
+	go func() {
+		defer s.waitGroup.Done()
+		defer close(returned)
+
+		result <- job.Do(ctx)
+	}()
+
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		select {
+		case err = <-result:
+		default:
+			job.Execution.lock.Lock()
+			job.Execution.abandoned = returned
+			job.Execution.lock.Unlock()
+
+			err = ctx.Err()
+		}
 	}
+
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return true, errors.Wrapf(err, "timed out after %s", job.Timeout)
+	}
+
+	return false, err
 }
 
-// schedule adds an execution to the run queue during the next interval
-// if the execution's state allows it (has not errored, isn't stopped).
+// schedule runs the job at the next run of its schedule until it has
+// errored, is stopped or has no next run. In between it handles the
+// requests of the Trigger, Pause, Resume and Stop methods.
 // Must be run in a go routine.
 func (s *Manager) schedule(job *Job) {
-	s.waitGroup.Add(1)
+	defer s.waitGroup.Done()
 
 	for {
-		sleep := time.Duration(0)
+		now := time.Now()
+		next := time.Time{}
 
-		if job.Execution.Runs == 0 {
-			sleep = job.Delay
-		} else {
-			sleep = job.Interval
+		job.Execution.lock.Lock()
+
+		if job.Execution.stopRequested || s.ctx.Err() != nil {
+			job.Execution.stopRequested = false
+			job.Execution.scheduled = false
+			job.Execution.State = StateStopped
+			job.Execution.NextRun = time.Time{}
+			job.Execution.lock.Unlock()
+
+			s.log.Infof("job %q stopped", job.Name)
+			return
 		}
 
-		if sleep > 0 {
-			s.log.Debugf("job %q going to sleep for: %s", job.Name, formatDuration(sleep))
+		if job.Execution.triggered {
+			job.Execution.triggered = false
+			job.Execution.lock.Unlock()
+
+			s.log.Infof("job %q triggered", job.Name)
 
-			sleepTimer := time.AfterFunc(sleep, func() {
-				job.Execution.signal <- SignalStart
-			})
+			if !s.run(job) {
+				return
+			}
+
+			continue
+		}
 
-			if signal := <-job.Execution.signal; signal == SignalStop {
-				sleepTimer.Stop()
+		if job.Execution.paused {
+			job.Execution.State = StatePaused
+			job.Execution.NextRun = time.Time{}
+			job.Execution.Retrying = false
+		} else if job.Execution.cooldown {
+			// the job stays errored until it is recovered
+			next = job.Execution.retryAt
+			job.Execution.NextRun = next
+			job.Execution.Retrying = true
+		} else {
+			next = job.nextRun(now)
 
-				job.Execution.lock.Lock()
+			if next.IsZero() {
+				job.Execution.scheduled = false
 				job.Execution.State = StateStopped
+				job.Execution.NextRun = time.Time{}
 				job.Execution.lock.Unlock()
-				s.log.Debugf("job %q stopped", job.Name)
-				break
+
+				s.log.Infof("job %q has no next run, stopping", job.Name)
+				return
+			}
+
+			retryAt := job.Execution.retryAt
+
+			// a timed out job stays timed out until its next run
+			if job.Execution.State != StateTimedOut {
+				job.Execution.State = StateWaiting
 			}
 
-			s.log.Debugf("job %q woken up", job.Name)
+			job.Execution.NextRun = next
+			job.Execution.Retrying = !retryAt.IsZero() && !next.Before(retryAt)
 		}
 
-		s.run(job)
+		job.Execution.lock.Unlock()
+
+		// a paused job waits for a signal only
+		var timer *time.Timer
+		var due <-chan time.Time
 
-		if job.Execution.State != StateWaiting {
-			break
+		if !next.IsZero() {
+			sleep := next.Sub(now)
+			s.log.Debugf("job %q going to sleep for: %s", job.Name, formatDuration(sleep))
+
+			timer = time.NewTimer(sleep)
+			due = timer.C
 		}
-	}
 
-	s.waitGroup.Done()
+		select {
+		case <-due:
+			if !s.run(job) {
+				return
+			}
+		case signal := <-job.Execution.signal:
+			if timer != nil {
+				timer.Stop()
+			}
+
+			s.log.Debugf("job %q received signal %d", job.Name, signal)
+		case <-s.ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+	}
 }
 
 func formatDuration(d time.Duration) string {
@@ -118,7 +398,7 @@ func (s *Manager) Jobs() []Job {
 	jobs := []Job{}
 
 	for _, job := range s.jobs {
-		jobs = append(jobs, *job)
+		jobs = append(jobs, job.snapshot())
 	}
 
 	return jobs
@@ -136,11 +416,7 @@ func (s *Manager) Job(jobName string) (Job, bool) {
 	j, ok := s.jobs[jobName]
 
 	if ok {
-		j.Execution.lock.Lock()
-		job := *j
-		j.Execution.lock.Unlock()
-
-		return job, true
+		return j.snapshot(), true
 	}
 
 	return Job{}, false
@@ -154,8 +430,8 @@ func (s *Manager) Start(jobs ...Job) error {
 
 	s.jobs = make(map[string]*Job)
 
-	for _, job := range jobs {
-		if job.Do == nil {
+	for i := range jobs {
+		if jobs[i].Do == nil {
 			return errors.New("job has no 'Do' function")
 		}
 	}
@@ -163,11 +439,185 @@ func (s *Manager) Start(jobs ...Job) error {
 	for i := range jobs {
 		job := &jobs[i]
 
-		job.Execution.signal = make(chan int)
+		job.Execution.signal = make(chan int, 1)
+		job.Execution.scheduled = true
 		s.jobs[job.Name] = job
 
+		s.waitGroup.Add(1)
 		go s.schedule(job)
 	}
 
 	return nil
 }
+
+// Trigger runs a job immediately, a paused job stays paused afterwards.
+// Jobs that were stopped or have errored are scheduled again.
+func (s *Manager) Trigger(jobName string) error {
+	job, err := s.job(jobName)
+
+	if err != nil {
+		return err
+	}
+
+	job.Execution.lock.Lock()
+	defer job.Execution.lock.Unlock()
+
+	if s.isShutdown() {
+		return errors.Wrapf(ErrInvalidState, "job %q can't run after the shutdown", jobName)
+	}
+
+	if job.Execution.waiting {
+		return errors.Wrapf(ErrInvalidState, "job %q waits for its previous run that timed out", jobName)
+	}
+
+	if job.Execution.State == StateRunning || job.Execution.triggered {
+		return errors.Wrapf(ErrInvalidState, "job %q is already running", jobName)
+	}
+
+	job.Execution.triggered = true
+	job.Execution.stopRequested = false
+
+	s.wake(job, SignalStart)
+
+	return nil
+}
+
+// Pause skips the runs of a job until it is resumed, a running
+// job is paused after it has finished.
+func (s *Manager) Pause(jobName string) error {
+	job, err := s.job(jobName)
+
+	if err != nil {
+		return err
+	}
+
+	job.Execution.lock.Lock()
+	defer job.Execution.lock.Unlock()
+
+	if !job.Execution.scheduled || job.Execution.stopRequested {
+		return errors.Wrapf(ErrInvalidState, "job %q is %s", jobName, job.Execution.State)
+	}
+
+	job.Execution.paused = true
+
+	s.wake(job, SignalPause)
+
+	return nil
+}
+
+// Resume continues the schedule of a paused job. Jobs that were
+// stopped or have errored are scheduled again.
+func (s *Manager) Resume(jobName string) error {
+	job, err := s.job(jobName)
+
+	if err != nil {
+		return err
+	}
+
+	if s.isShutdown() {
+		return errors.Wrapf(ErrInvalidState, "job %q can't resume after the shutdown", jobName)
+	}
+
+	job.Execution.lock.Lock()
+	defer job.Execution.lock.Unlock()
+
+	job.Execution.paused = false
+	job.Execution.stopRequested = false
+
+	if job.Execution.cooldown {
+		job.Execution.cooldown = false
+		job.Execution.retryAt = time.Time{}
+	}
+
+	s.wake(job, SignalResume)
+
+	return nil
+}
+
+// Stop stops scheduling a job, a running job is stopped after it has
+// finished. Stopped jobs can be started again with Resume or Trigger.
+func (s *Manager) Stop(jobName string) error {
+	job, err := s.job(jobName)
+
+	if err != nil {
+		return err
+	}
+
+	job.Execution.lock.Lock()
+	defer job.Execution.lock.Unlock()
+
+	if !job.Execution.scheduled {
+		return nil
+	}
+
+	job.Execution.paused = false
+	job.Execution.triggered = false
+	job.Execution.stopRequested = true
+
+	s.wake(job, SignalStop)
+
+	return nil
+}
+
+func (s *Manager) job(jobName string) (*Job, error) {
+	job, ok := s.jobs[jobName]
+
+	if !ok {
+		return nil, errors.Wrapf(ErrNotFound, "job %q", jobName)
+	}
+
+	return job, nil
+}
+
+// wake sends a signal to the schedule go routine of a job, if the
+// job isn't scheduled anymore a new go routine is started.
+// The execution lock must be held.
+func (s *Manager) wake(job *Job, signal int) {
+	if job.Execution.scheduled {
+		job.wake(signal)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.shutdown {
+		return
+	}
+
+	job.Execution.scheduled = true
+
+	s.waitGroup.Add(1)
+	go s.schedule(job)
+}
+
+// Shutdown stops scheduling the jobs and cancels the context of the running
+// jobs, then it waits until they have returned or `ctx` is done.
+func (s *Manager) Shutdown(ctx context.Context) error {
+	s.lock.Lock()
+	s.shutdown = true
+	s.lock.Unlock()
+
+	s.cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.waitGroup.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "wait for running jobs")
+	}
+}
+
+func (s *Manager) isShutdown() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.shutdown
+}
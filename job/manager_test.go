@@ -1,9 +1,13 @@
 package job
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/raphi011/scores-api/test"
 )
 
@@ -17,7 +21,7 @@ func TestManager(t *testing.T) {
 			Interval: 1 * time.Second,
 			MaxRuns:  3,
 
-			Do: func() error {
+			Do: func(ctx context.Context) error {
 				return nil
 			},
 		},
@@ -32,3 +36,159 @@ func TestManager(t *testing.T) {
 	test.Assert(t, "manager.Job() can't retrieve a job", ok)
 	test.Assert(t, "expected job to execute 3 times, got %d", j.Execution.Runs == 3, j.Execution.Runs)
 }
+
+// waitForState waits until the job is in `state` and returns its # of runs.
+func waitForState(t *testing.T, manager *Manager, jobName string, state State) uint {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		j, _ := manager.Job(jobName)
+
+		if j.Execution.State == state {
+			return j.Execution.Runs
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("job %q want state %s, got %s", jobName, state, j.Execution.State)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestManagerControl(t *testing.T) {
+	manager := NewManager()
+
+	runs := make(chan struct{}, 10)
+
+	err := manager.Start(
+		Job{
+			Name:     "Test",
+			Interval: time.Hour,
+			Delay:    time.Hour,
+			Do: func(ctx context.Context) error {
+				runs <- struct{}{}
+				return nil
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	waitForState(t, manager, "Test", StateWaiting)
+
+	test.Check(t, "manager.Trigger() failed: %v", manager.Trigger("Test"))
+	<-runs
+	runCount := waitForState(t, manager, "Test", StateWaiting)
+	test.Assert(t, "expected job to run once, got %d", runCount == 1, runCount)
+
+	test.Check(t, "manager.Pause() failed: %v", manager.Pause("Test"))
+	waitForState(t, manager, "Test", StatePaused)
+
+	// a triggered job stays paused
+	test.Check(t, "manager.Trigger() failed: %v", manager.Trigger("Test"))
+	<-runs
+	waitForState(t, manager, "Test", StatePaused)
+
+	test.Check(t, "manager.Resume() failed: %v", manager.Resume("Test"))
+	waitForState(t, manager, "Test", StateWaiting)
+
+	test.Check(t, "manager.Stop() failed: %v", manager.Stop("Test"))
+	waitForState(t, manager, "Test", StateStopped)
+
+	err = manager.Pause("Test")
+	test.Assert(t, "manager.Pause() of a stopped job want ErrInvalidState, got %v", errors.Cause(err) == ErrInvalidState, err)
+
+	// a stopped job is scheduled again
+	test.Check(t, "manager.Trigger() failed: %v", manager.Trigger("Test"))
+	<-runs
+	runCount = waitForState(t, manager, "Test", StateWaiting)
+	test.Assert(t, "expected job to run 3 times, got %d", runCount == 3, runCount)
+
+	err = manager.Trigger("Unknown")
+	test.Assert(t, "manager.Trigger() of an unknown job want ErrNotFound, got %v", errors.Cause(err) == ErrNotFound, err)
+}
+
+func TestManagerShutdown(t *testing.T) {
+	manager := NewManager()
+
+	running := make(chan struct{})
+
+	err := manager.Start(
+		Job{
+			Name: "Test",
+			Do: func(ctx context.Context) error {
+				close(running)
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	<-running
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	test.Check(t, "manager.Shutdown() failed: %v", manager.Shutdown(ctx))
+
+	j, _ := manager.Job("Test")
+	test.Equal(t, "expected job state %s, got %s", StateStopped, j.Execution.State)
+
+	err = manager.Trigger("Test")
+	test.Assert(t, "manager.Trigger() after the shutdown want ErrInvalidState, got %v", errors.Cause(err) == ErrInvalidState, err)
+}
+
+func TestManagerTimeout(t *testing.T) {
+	manager := NewManager()
+
+	var runs int32
+	release := make(chan struct{})
+
+	err := manager.Start(
+		Job{
+			Name:     "Test",
+			Interval: time.Hour,
+			Timeout:  20 * time.Millisecond,
+			Do: func(ctx context.Context) error {
+				if atomic.AddInt32(&runs, 1) > 1 {
+					return nil
+				}
+
+				// ignores the cancellation of its context
+				<-release
+
+				return nil
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	waitForState(t, manager, "Test", StateTimedOut)
+
+	j, _ := manager.Job("Test")
+	test.Equal(t, "expected state %q, got %q", "timed-out", j.Execution.State.String())
+	test.Assert(t, "expected the timeout to count as a failure, got %d errors", len(j.Execution.Errors) == 1, len(j.Execution.Errors))
+
+	// the next run waits for the run that timed out
+	test.Check(t, "manager.Trigger() failed: %v", manager.Trigger("Test"))
+	time.Sleep(50 * time.Millisecond)
+	test.Equal(t, "expected the runs not to overlap, got %d runs", int32(1), atomic.LoadInt32(&runs))
+
+	j, _ = manager.Job("Test")
+	test.Equal(t, "expected the waiting run to keep the state %s, got %s", StateTimedOut, j.Execution.State)
+
+	err = manager.Trigger("Test")
+	test.Assert(t, "manager.Trigger() while waiting want ErrInvalidState, got %v", errors.Cause(err) == ErrInvalidState, err)
+
+	close(release)
+
+	runCount := waitForState(t, manager, "Test", StateWaiting)
+	test.Assert(t, "expected job to run twice, got %d", runCount == 2, runCount)
+}
@@ -0,0 +1,78 @@
+package job
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metrics = struct {
+	running  *runningJobs
+	timeouts *prometheus.CounterVec
+}{
+	running: newRunningJobs(),
+	timeouts: promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "api_job_timeouts",
+		Help: "The total number of job runs that timed out",
+	}, []string{"job"}),
+}
+
+// runningJobs reports how long the running jobs have been running.
+type runningJobs struct {
+	desc *prometheus.Desc
+
+	lock  sync.Mutex
+	start map[string]time.Time
+}
+
+func newRunningJobs() *runningJobs {
+	r := &runningJobs{
+		desc: prometheus.NewDesc(
+			"api_job_running_duration_seconds",
+			"How long the current run of a job has been running, a job that doesn't run has no value",
+			[]string{"job"},
+			nil,
+		),
+		start: map[string]time.Time{},
+	}
+
+	prometheus.MustRegister(r)
+
+	return r
+}
+
+func (r *runningJobs) started(jobName string, start time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.start[jobName] = start
+}
+
+func (r *runningJobs) finished(jobName string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.start, jobName)
+}
+
+// Describe implements prometheus.Collector.
+func (r *runningJobs) Describe(ch chan<- *prometheus.Desc) {
+	ch <- r.desc
+}
+
+// Collect implements prometheus.Collector.
+func (r *runningJobs) Collect(ch chan<- prometheus.Metric) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for jobName, start := range r.start {
+		ch <- prometheus.MustNewConstMetric(
+			r.desc,
+			prometheus.GaugeValue,
+			time.Since(start).Seconds(),
+			jobName,
+		)
+	}
+}
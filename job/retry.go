@@ -0,0 +1,46 @@
+package job
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy decides when a failed job is attempted again. The delay
+// after the first failure doubles with every consecutive failure up to
+// `MaxDelay`. If the job is retried later than its schedule would run
+// it next, it runs according to its schedule.
+type RetryPolicy struct {
+	Delay    time.Duration `json:"delay"`    // the delay after the first failure
+	MaxDelay time.Duration `json:"maxDelay"` // caps the delay, no cap if 0
+	Jitter   float64       `json:"jitter"`   // randomizes the delay by up to this fraction, e.g. 0.1 for ±10%
+	Cooldown time.Duration `json:"cooldown"` // errored jobs are recovered after the cooldown, never if 0
+}
+
+// delay returns the delay after `failures` consecutive failures.
+func (p *RetryPolicy) delay(failures int) time.Duration {
+	delay := p.Delay
+
+	for i := 1; i < failures && (p.MaxDelay == 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return p.jitter(delay)
+}
+
+func (p *RetryPolicy) jitter(delay time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return delay
+	}
+
+	delay += time.Duration(float64(delay) * p.Jitter * (2*rand.Float64() - 1))
+
+	if delay < 0 {
+		return 0
+	}
+
+	return delay
+}
@@ -0,0 +1,102 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{Delay: time.Second, MaxDelay: 3 * time.Second}
+
+	for failures, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 3 * time.Second, 10: 3 * time.Second} {
+		got := policy.delay(failures)
+		test.Equal(t, "delay() want %s, got %s", want, got)
+	}
+
+	policy.Jitter = 0.5
+
+	for i := 0; i < 100; i++ {
+		got := policy.delay(1)
+		test.Assert(t, "delay() with jitter want between 0.5s and 1.5s, got %s", got >= 500*time.Millisecond && got <= 1500*time.Millisecond, got)
+	}
+}
+
+func TestManagerRetriesFailedRuns(t *testing.T) {
+	manager := NewManager()
+
+	var runs int32
+
+	err := manager.Start(
+		Job{
+			Name:     "Test",
+			Interval: time.Hour,
+			Retry:    &RetryPolicy{Delay: 20 * time.Millisecond},
+			Do: func(ctx context.Context) error {
+				if atomic.AddInt32(&runs, 1) < 3 {
+					return errors.New("volleynet is down")
+				}
+
+				return nil
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	timeout := time.After(5 * time.Second)
+
+	for atomic.LoadInt32(&runs) < 3 {
+		select {
+		case <-timeout:
+			t.Fatalf("expected the job to be retried, ran %d times", atomic.LoadInt32(&runs))
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	// the job succeeded and waits for its next scheduled run
+	waitForState(t, manager, "Test", StateWaiting)
+
+	j, _ := manager.Job("Test")
+	test.Assert(t, "expected the next run not to be a retry", !j.Execution.Retrying)
+	test.Assert(t, "expected the next run in an hour, got %s", j.Execution.NextRun.After(time.Now().Add(59*time.Minute)), j.Execution.NextRun)
+}
+
+func TestManagerRecoversErroredJobs(t *testing.T) {
+	manager := NewManager()
+
+	var runs int32
+
+	err := manager.Start(
+		Job{
+			Name:        "Test",
+			Interval:    time.Hour,
+			MaxFailures: 2,
+			Retry:       &RetryPolicy{Delay: 10 * time.Millisecond, Cooldown: 200 * time.Millisecond},
+			Do: func(ctx context.Context) error {
+				if atomic.AddInt32(&runs, 1) <= 2 {
+					return errors.New("volleynet is down")
+				}
+
+				return nil
+			},
+		},
+	)
+
+	test.Check(t, "manager.Start() failed: %v", err)
+
+	waitForState(t, manager, "Test", StateErrored)
+
+	j, _ := manager.Job("Test")
+	test.Assert(t, "expected the recovery to be scheduled", j.Execution.Retrying && !j.Execution.NextRun.IsZero())
+
+	runCount := waitForState(t, manager, "Test", StateWaiting)
+	test.Assert(t, "expected job to run 3 times, got %d", runCount == 3, runCount)
+
+	j, _ = manager.Job("Test")
+	test.Assert(t, "expected no errors after the recovery, got %v", len(j.Execution.Errors) == 0, j.Execution.Errors)
+}
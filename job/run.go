@@ -0,0 +1,114 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// outcomes of a run.
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+	OutcomeTimedOut  = "timed-out"
+)
+
+// maxRunLogSize limits the size of the log of a run, later lines are dropped.
+const maxRunLogSize = 64 * 1024
+
+// Run is a finished run of a job.
+type Run struct {
+	Job     string
+	Start   time.Time
+	End     time.Time
+	Outcome string
+	Error   string
+	Log     string // the lines that were logged during the run
+}
+
+// Recorder persists the runs of the jobs.
+type Recorder interface {
+	Record(run *Run) error
+}
+
+type loggerKey struct{}
+
+// Logger returns the logger of the run that `ctx` belongs to, the lines
+// are captured in the log of the run. Outside of a run it returns the
+// global logger.
+func Logger(ctx context.Context) *zap.SugaredLogger {
+	if log, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ok {
+		return log
+	}
+
+	return zap.S()
+}
+
+func withLogger(ctx context.Context, log *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// runLog captures the log lines of a run.
+type runLog struct {
+	lock      sync.Mutex
+	buffer    bytes.Buffer
+	truncated bool
+}
+
+func (l *runLog) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.buffer.Len()+len(p) > maxRunLogSize {
+		l.truncated = true
+		return len(p), nil
+	}
+
+	return l.buffer.Write(p)
+}
+
+func (l *runLog) Sync() error {
+	return nil
+}
+
+func (l *runLog) String() string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.truncated {
+		return l.buffer.String() + "[log truncated]\n"
+	}
+
+	return l.buffer.String()
+}
+
+// newRunLogger returns a logger for a run of `job` that writes to the
+// manager's log and captures the lines at debug level and above.
+func (s *Manager) newRunLogger(job *Job) (*zap.SugaredLogger, *runLog) {
+	captured := &runLog{}
+
+	encoderConfig := zap.NewDevelopmentEncoderConfig()
+	encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+
+	core := zapcore.NewTee(
+		s.log.Desugar().Core(),
+		zapcore.NewCore(zapcore.NewConsoleEncoder(encoderConfig), captured, zap.DebugLevel),
+	)
+
+	return zap.New(core).Sugar().With("job", job.Name), captured
+}
+
+// record persists a finished run if the manager has a recorder.
+func (s *Manager) record(run *Run) {
+	if s.Recorder == nil {
+		return
+	}
+
+	if err := s.Recorder.Record(run); err != nil {
+		s.log.Warnf("could not record run of job %q: %v", run.Job, err)
+	}
+}
@@ -0,0 +1,37 @@
+package job
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule decides when a job runs next.
+type Schedule interface {
+	// Next returns the first time after `t` the job should run.
+	Next(t time.Time) time.Time
+	// String describes the schedule, e.g. in the job report.
+	String() string
+}
+
+// Every returns a schedule that runs a job every `interval`.
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Next returns `t` + the interval.
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+func (s intervalSchedule) String() string {
+	return fmt.Sprintf("every %s", s.interval)
+}
+
+// MarshalText describes the schedule in the job report.
+func (s intervalSchedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -12,6 +12,10 @@ const (
 	StateRunning
 	// StateErrored is set if a job is in an error state
 	StateErrored
+	// StatePaused is set if a job was paused, it is not run until it is resumed
+	StatePaused
+	// StateTimedOut is set if the last run of a job took longer than its timeout
+	StateTimedOut
 )
 
 // String returns the State alias
@@ -25,6 +29,10 @@ func (s State) String() string {
 		return "running"
 	case StateErrored:
 		return "errored"
+	case StatePaused:
+		return "paused"
+	case StateTimedOut:
+		return "timed-out"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,18 @@
+package scores
+
+import (
+	"time"
+)
+
+// JobRun is a finished run of a job with the lines that were logged while it ran.
+type JobRun struct {
+	M
+	Track
+	Job        string    `json:"job"`
+	Start      time.Time `json:"start" db:"start_date"`
+	End        time.Time `json:"end" db:"end_date"`
+	DurationMS int64     `json:"durationMs" db:"duration_ms"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error"`
+	Log        string    `json:"log,omitempty"`
+}
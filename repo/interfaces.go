@@ -1,6 +1,8 @@
 package repo
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/raphi011/scores-api"
 	"github.com/raphi011/scores-api/volleynet"
@@ -22,6 +24,9 @@ type PlayerRepository interface {
 	ByGender(gender string) ([]*volleynet.Player, error)
 	PreviousPartners(playerID int) ([]*volleynet.Player, error)
 	Search(filter PlayerFilter) ([]*volleynet.Player, error)
+
+	Clubs(playerID int) ([]*volleynet.PlayerClub, error)
+	NewClubs(clubs ...*volleynet.PlayerClub) error
 }
 
 // TeamRepository exposes CRUD operations on teams.
@@ -34,6 +39,13 @@ type TeamRepository interface {
 	UpdateBatch(t ...*volleynet.TournamentTeam) error
 }
 
+// MatchRepository exposes CRUD operations on matches.
+type MatchRepository interface {
+	ByTournament(tournamentID int) ([]*volleynet.Match, error)
+	New(m *volleynet.Match) (*volleynet.Match, error)
+	NewBatch(m ...*volleynet.Match) error
+}
+
 // TournamentFilter contains all available Tournament filters.
 type TournamentFilter struct {
 	Seasons []string
@@ -72,11 +84,85 @@ type SettingRepository interface {
 	ByUserID(userID uuid.UUID) ([]*scores.Setting, error)
 }
 
+// SyncRunRepository exposes CRUD operations on sync runs.
+type SyncRunRepository interface {
+	New(r *volleynet.SyncRun) (*volleynet.SyncRun, error)
+	Update(r *volleynet.SyncRun) error
+	Delete(r *volleynet.SyncRun) error
+	Page(offset, limit int) ([]*volleynet.SyncRun, error)
+	Count() (int, error)
+}
+
+// TournamentChangeRepository exposes CRUD operations on tournament changes.
+type TournamentChangeRepository interface {
+	NewBatch(c ...*volleynet.TournamentChange) error
+	ByTournament(tournamentID int) ([]*volleynet.TournamentChange, error)
+}
+
+// WebhookRepository exposes CRUD operations on webhooks.
+type WebhookRepository interface {
+	All() ([]*scores.Webhook, error)
+	Get(id int) (*scores.Webhook, error)
+	New(w *scores.Webhook) (*scores.Webhook, error)
+	Delete(w *scores.Webhook) error
+}
+
+// WebhookDeliveryRepository exposes CRUD operations on webhook deliveries.
+type WebhookDeliveryRepository interface {
+	Get(id int) (*scores.WebhookDelivery, error)
+	New(d *scores.WebhookDelivery) (*scores.WebhookDelivery, error)
+	Update(d *scores.WebhookDelivery) error
+	Due(now time.Time, limit int) ([]*scores.WebhookDelivery, error)
+	Claim(d *scores.WebhookDelivery, now, until time.Time) (bool, error)
+	Page(webhookID, offset, limit int) ([]*scores.WebhookDelivery, error)
+	WebhookIDs(eventID uint64) ([]int, error)
+	LastEventID() (uint64, error)
+}
+
+// EventLogRepository exposes CRUD operations on the event log, the
+// entries are ordered by their sequence number.
+type EventLogRepository interface {
+	New(e *scores.EventLogEntry) (*scores.EventLogEntry, error)
+	Since(id, limit int) ([]*scores.EventLogEntry, error)
+	From(from time.Time, id, limit int) ([]*scores.EventLogEntry, error)
+}
+
+// JobRunRepository exposes CRUD operations on job runs.
+type JobRunRepository interface {
+	New(r *scores.JobRun) (*scores.JobRun, error)
+	Get(id int) (*scores.JobRun, error)
+	Page(jobName string, offset, limit int) ([]*scores.JobRun, error)
+	Count(jobName string) (int, error)
+}
+
+// JobLeaseRepository grants leases on jobs to the instances of the api.
+type JobLeaseRepository interface {
+	Acquire(jobName, holder string, ttl time.Duration) (bool, error)
+	Release(jobName, holder string) error
+}
+
+// UnitOfWork runs repository operations within a transaction.
+type UnitOfWork interface {
+	// Do passes repositories to `work` whose changes are committed
+	// if `work` returns nil and are rolled back otherwise.
+	Do(work func(repos *Repositories) error) error
+}
+
 // Repositories is a collection of instances of all available repositories.
 type Repositories struct {
+	MatchRepo      MatchRepository
 	PlayerRepo     PlayerRepository
 	TeamRepo       TeamRepository
 	TournamentRepo TournamentRepository
 	UserRepo       UserRepository
 	SettingRepo    SettingRepository
+	SyncRunRepo    SyncRunRepository
+	ChangeRepo     TournamentChangeRepository
+	WebhookRepo    WebhookRepository
+	DeliveryRepo   WebhookDeliveryRepository
+	EventLogRepo   EventLogRepository
+	JobRunRepo     JobRunRepository
+	JobLeaseRepo   JobLeaseRepository
+
+	UnitOfWork UnitOfWork
 }
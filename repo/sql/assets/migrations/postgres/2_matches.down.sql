@@ -0,0 +1 @@
+DROP TABLE matches;
\ No newline at end of file
@@ -0,0 +1,27 @@
+CREATE TABLE matches (
+	tournament_id       int         NOT NULL REFERENCES tournaments(id),
+	match_nr            smallint    NOT NULL,
+
+	created_at          timestamptz NOT NULL,
+	updated_at          timestamptz,
+	deleted_at          timestamptz,
+
+	round               text        NOT NULL,
+	court               smallint    NOT NULL,
+	team_1_player_1_id  int         NOT NULL REFERENCES players(id),
+	team_1_player_2_id  int         NOT NULL REFERENCES players(id),
+	team_2_player_1_id  int         NOT NULL REFERENCES players(id),
+	team_2_player_2_id  int         NOT NULL REFERENCES players(id),
+	set_1_team_1        smallint    NOT NULL,
+	set_1_team_2        smallint    NOT NULL,
+	set_2_team_1        smallint    NOT NULL,
+	set_2_team_2        smallint    NOT NULL,
+	set_3_team_1        smallint    NOT NULL,
+	set_3_team_2        smallint    NOT NULL,
+	winner              smallint    NOT NULL,
+
+	PRIMARY KEY (tournament_id, match_nr)
+);
+
+CREATE INDEX matches_team_1 ON matches (team_1_player_1_id, team_1_player_2_id);
+CREATE INDEX matches_team_2 ON matches (team_2_player_1_id, team_2_player_2_id);
@@ -0,0 +1,11 @@
+CREATE TABLE player_clubs (
+	player_id       int         NOT NULL REFERENCES players(id),
+	season          text        NOT NULL,
+	club            text        NOT NULL,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	PRIMARY KEY (player_id, season, club)
+);
@@ -0,0 +1,24 @@
+CREATE TABLE sync_runs (
+	id                  serial      PRIMARY KEY,
+
+	created_at          timestamptz NOT NULL,
+	updated_at          timestamptz,
+	deleted_at          timestamptz,
+
+	type                text        NOT NULL,
+	parameters          text        NOT NULL,
+	start_date          timestamptz NOT NULL,
+	end_date            timestamptz,
+	duration_ms         bigint      NOT NULL,
+	new_tournaments     int         NOT NULL,
+	updated_tournaments int         NOT NULL,
+	deleted_tournaments int         NOT NULL,
+	new_teams           int         NOT NULL,
+	updated_teams       int         NOT NULL,
+	deleted_teams       int         NOT NULL,
+	new_players         int         NOT NULL,
+	updated_players     int         NOT NULL,
+	error               text        NOT NULL
+);
+
+CREATE INDEX sync_runs_start_date ON sync_runs (start_date);
@@ -0,0 +1,17 @@
+CREATE TABLE tournament_changes (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	tournament_id   int         NOT NULL REFERENCES tournaments(id),
+	team            text        NOT NULL,
+	field           text        NOT NULL,
+	old_value       text        NOT NULL,
+	new_value       text        NOT NULL,
+	sync_run_id     int         REFERENCES sync_runs(id),
+	changed_at      timestamptz NOT NULL
+);
+
+CREATE INDEX tournament_changes_tournament ON tournament_changes (tournament_id, changed_at);
@@ -0,0 +1,34 @@
+CREATE TABLE webhooks (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	url             text        NOT NULL,
+	events          text        NOT NULL,
+	secret          text        NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	webhook_id      int         NOT NULL REFERENCES webhooks(id),
+	event_id        bigint      NOT NULL,
+	event_name      text        NOT NULL,
+	payload         text        NOT NULL,
+	status          text        NOT NULL,
+	attempts        int         NOT NULL,
+	next_attempt    timestamptz,
+	response_status int         NOT NULL,
+	last_error      text        NOT NULL,
+	delivered_at    timestamptz
+);
+
+CREATE INDEX webhook_deliveries_webhook ON webhook_deliveries (webhook_id, created_at);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt);
+CREATE INDEX webhook_deliveries_event ON webhook_deliveries (event_id);
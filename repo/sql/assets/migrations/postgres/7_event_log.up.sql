@@ -0,0 +1,12 @@
+CREATE TABLE event_log (
+	id              bigserial   PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	name            text        NOT NULL,
+	body            text        NOT NULL
+);
+
+CREATE INDEX event_log_created_at ON event_log (created_at);
@@ -0,0 +1,17 @@
+CREATE TABLE job_runs (
+	id              serial      PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+	deleted_at      timestamptz,
+
+	job             text        NOT NULL,
+	start_date      timestamptz NOT NULL,
+	end_date        timestamptz NOT NULL,
+	duration_ms     bigint      NOT NULL,
+	outcome         text        NOT NULL,
+	error           text        NOT NULL,
+	log             text        NOT NULL
+);
+
+CREATE INDEX job_runs_job_start_date ON job_runs (job, start_date);
@@ -0,0 +1,9 @@
+CREATE TABLE job_leases (
+	job             text        PRIMARY KEY,
+
+	created_at      timestamptz NOT NULL,
+	updated_at      timestamptz,
+
+	holder          text        NOT NULL,
+	expires_at      timestamptz NOT NULL
+);
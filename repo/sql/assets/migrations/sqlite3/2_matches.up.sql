@@ -0,0 +1,29 @@
+CREATE TABLE matches (
+	tournament_id integer NOT NULL,
+	match_nr integer NOT NULL,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	round varchar(128) NOT NULL,
+	court integer NOT NULL,
+	team_1_player_1_id integer NOT NULL,
+	team_1_player_2_id integer NOT NULL,
+	team_2_player_1_id integer NOT NULL,
+	team_2_player_2_id integer NOT NULL,
+	set_1_team_1 integer NOT NULL,
+	set_1_team_2 integer NOT NULL,
+	set_2_team_1 integer NOT NULL,
+	set_2_team_2 integer NOT NULL,
+	set_3_team_1 integer NOT NULL,
+	set_3_team_2 integer NOT NULL,
+	winner integer NOT NULL,
+
+	FOREIGN KEY(tournament_id) REFERENCES tournaments(id),
+	FOREIGN KEY(team_1_player_1_id) REFERENCES players(id),
+	FOREIGN KEY(team_1_player_2_id) REFERENCES players(id),
+	FOREIGN KEY(team_2_player_1_id) REFERENCES players(id),
+	FOREIGN KEY(team_2_player_2_id) REFERENCES players(id),
+	PRIMARY KEY(tournament_id, match_nr)
+);
@@ -0,0 +1 @@
+DROP TABLE player_clubs;
\ No newline at end of file
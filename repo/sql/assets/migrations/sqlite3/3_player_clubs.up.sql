@@ -0,0 +1,12 @@
+CREATE TABLE player_clubs (
+	player_id integer NOT NULL,
+	season varchar(16) NOT NULL,
+	club varchar(255) NOT NULL,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	FOREIGN KEY(player_id) REFERENCES players(id),
+	PRIMARY KEY(player_id, season, club)
+);
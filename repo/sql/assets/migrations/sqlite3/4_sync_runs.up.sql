@@ -0,0 +1,24 @@
+CREATE TABLE sync_runs (
+	id integer PRIMARY KEY AUTOINCREMENT,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	type varchar(64) NOT NULL,
+	parameters varchar(255) NOT NULL,
+	start_date datetime NOT NULL,
+	end_date datetime,
+	duration_ms integer NOT NULL,
+	new_tournaments integer NOT NULL,
+	updated_tournaments integer NOT NULL,
+	deleted_tournaments integer NOT NULL,
+	new_teams integer NOT NULL,
+	updated_teams integer NOT NULL,
+	deleted_teams integer NOT NULL,
+	new_players integer NOT NULL,
+	updated_players integer NOT NULL,
+	error text NOT NULL
+);
+
+CREATE INDEX sync_runs_start_date ON sync_runs (start_date);
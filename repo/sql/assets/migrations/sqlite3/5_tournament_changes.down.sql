@@ -0,0 +1 @@
+DROP TABLE tournament_changes;
\ No newline at end of file
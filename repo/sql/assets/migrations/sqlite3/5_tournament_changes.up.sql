@@ -0,0 +1,20 @@
+CREATE TABLE tournament_changes (
+	id integer PRIMARY KEY AUTOINCREMENT,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	tournament_id integer NOT NULL,
+	team varchar(64) NOT NULL,
+	field varchar(64) NOT NULL,
+	old_value text NOT NULL,
+	new_value text NOT NULL,
+	sync_run_id integer,
+	changed_at datetime NOT NULL,
+
+	FOREIGN KEY(tournament_id) REFERENCES tournaments(id),
+	FOREIGN KEY(sync_run_id) REFERENCES sync_runs(id)
+);
+
+CREATE INDEX tournament_changes_tournament ON tournament_changes (tournament_id, changed_at);
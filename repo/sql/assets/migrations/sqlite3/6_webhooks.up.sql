@@ -0,0 +1,36 @@
+CREATE TABLE webhooks (
+	id integer PRIMARY KEY AUTOINCREMENT,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	url text NOT NULL,
+	events text NOT NULL,
+	secret varchar(255) NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+	id integer PRIMARY KEY AUTOINCREMENT,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	webhook_id integer NOT NULL,
+	event_id integer NOT NULL,
+	event_name varchar(255) NOT NULL,
+	payload text NOT NULL,
+	status varchar(16) NOT NULL,
+	attempts integer NOT NULL,
+	next_attempt datetime,
+	response_status integer NOT NULL,
+	last_error text NOT NULL,
+	delivered_at datetime,
+
+	FOREIGN KEY(webhook_id) REFERENCES webhooks(id)
+);
+
+CREATE INDEX webhook_deliveries_webhook ON webhook_deliveries (webhook_id, created_at);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt);
+CREATE INDEX webhook_deliveries_event ON webhook_deliveries (event_id);
@@ -0,0 +1,12 @@
+CREATE TABLE event_log (
+	id integer PRIMARY KEY AUTOINCREMENT,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	name varchar(255) NOT NULL,
+	body text NOT NULL
+);
+
+CREATE INDEX event_log_created_at ON event_log (created_at);
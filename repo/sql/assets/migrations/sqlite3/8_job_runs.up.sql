@@ -0,0 +1,17 @@
+CREATE TABLE job_runs (
+	id integer PRIMARY KEY AUTOINCREMENT,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+	deleted_at datetime,
+
+	job varchar(255) NOT NULL,
+	start_date datetime NOT NULL,
+	end_date datetime NOT NULL,
+	duration_ms integer NOT NULL,
+	outcome varchar(64) NOT NULL,
+	error text NOT NULL,
+	log text NOT NULL
+);
+
+CREATE INDEX job_runs_job_start_date ON job_runs (job, start_date);
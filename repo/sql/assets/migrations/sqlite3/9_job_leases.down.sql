@@ -0,0 +1 @@
+DROP TABLE job_leases;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE job_leases (
+	job varchar(255) PRIMARY KEY,
+
+	created_at datetime NOT NULL,
+	updated_at datetime,
+
+	holder varchar(255) NOT NULL,
+	expires_at datetime NOT NULL
+);
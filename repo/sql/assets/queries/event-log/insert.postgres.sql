@@ -0,0 +1,13 @@
+INSERT INTO event_log
+(
+	created_at,
+	name,
+	body
+)
+VALUES
+(
+	:created_at,
+	:name,
+	:body
+)
+RETURNING id
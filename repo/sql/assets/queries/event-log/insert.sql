@@ -0,0 +1,12 @@
+INSERT INTO event_log
+(
+	created_at,
+	name,
+	body
+)
+VALUES
+(
+	:created_at,
+	:name,
+	:body
+)
@@ -0,0 +1,9 @@
+SELECT
+	e.id,
+	e.created_at,
+	e.name,
+	e.body
+FROM event_log e
+WHERE e.created_at >= ? AND e.id > ?
+ORDER BY e.id
+LIMIT ?
@@ -0,0 +1,19 @@
+INSERT INTO job_leases
+(
+	job,
+	holder,
+	created_at,
+	expires_at
+)
+VALUES
+(
+	?,
+	?,
+	strftime('%Y-%m-%d %H:%M:%f', 'now'),
+	strftime('%Y-%m-%d %H:%M:%f', 'now', ? || ' seconds')
+)
+ON CONFLICT (job) DO UPDATE SET
+	holder = excluded.holder,
+	updated_at = excluded.created_at,
+	expires_at = excluded.expires_at
+WHERE job_leases.holder = excluded.holder OR job_leases.expires_at <= excluded.created_at
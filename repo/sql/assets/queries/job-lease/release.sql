@@ -0,0 +1,2 @@
+DELETE FROM job_leases
+WHERE job = ? AND holder = ?
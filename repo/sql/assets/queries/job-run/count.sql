@@ -0,0 +1,2 @@
+SELECT COUNT(*) FROM job_runs r
+WHERE ? = '' OR r.job = ?
@@ -0,0 +1,23 @@
+INSERT INTO job_runs
+(
+	created_at,
+	job,
+	start_date,
+	end_date,
+	duration_ms,
+	outcome,
+	error,
+	log
+)
+VALUES
+(
+	:created_at,
+	:job,
+	:start_date,
+	:end_date,
+	:duration_ms,
+	:outcome,
+	:error,
+	:log
+)
+RETURNING id
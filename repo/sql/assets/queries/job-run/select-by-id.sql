@@ -0,0 +1,13 @@
+SELECT
+	r.id,
+	r.created_at,
+	r.updated_at,
+	r.job,
+	r.start_date,
+	r.end_date,
+	r.duration_ms,
+	r.outcome,
+	r.error,
+	r.log
+FROM job_runs r
+WHERE r.id = ?
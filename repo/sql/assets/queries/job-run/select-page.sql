@@ -0,0 +1,14 @@
+SELECT
+	r.id,
+	r.created_at,
+	r.updated_at,
+	r.job,
+	r.start_date,
+	r.end_date,
+	r.duration_ms,
+	r.outcome,
+	r.error
+FROM job_runs r
+WHERE ? = '' OR r.job = ?
+ORDER BY r.start_date DESC, r.id DESC
+LIMIT ? OFFSET ?
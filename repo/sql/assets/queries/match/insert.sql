@@ -0,0 +1,38 @@
+INSERT INTO matches
+(
+	created_at,
+	tournament_id,
+	match_nr,
+	round,
+	court,
+	team_1_player_1_id,
+	team_1_player_2_id,
+	team_2_player_1_id,
+	team_2_player_2_id,
+	set_1_team_1,
+	set_1_team_2,
+	set_2_team_1,
+	set_2_team_2,
+	set_3_team_1,
+	set_3_team_2,
+	winner
+)
+VALUES
+(
+	:created_at,
+	:tournament_id,
+	:match_nr,
+	:round,
+	:court,
+	:team_1.player1.id,
+	:team_1.player2.id,
+	:team_2.player1.id,
+	:team_2.player2.id,
+	:set_1.team_1,
+	:set_1.team_2,
+	:set_2.team_1,
+	:set_2.team_2,
+	:set_3.team_1,
+	:set_3.team_2,
+	:winner
+)
\ No newline at end of file
@@ -0,0 +1,37 @@
+SELECT
+	m.tournament_id,
+	m.match_nr,
+	m.created_at,
+	m.updated_at,
+	m.round,
+	m.court,
+	m.team_1_player_1_id as "team_1.player1.id",
+	p11.first_name as "team_1.player1.first_name",
+	p11.last_name as "team_1.player1.last_name",
+	p11.gender as "team_1.player1.gender",
+	m.team_1_player_2_id as "team_1.player2.id",
+	p12.first_name as "team_1.player2.first_name",
+	p12.last_name as "team_1.player2.last_name",
+	p12.gender as "team_1.player2.gender",
+	m.team_2_player_1_id as "team_2.player1.id",
+	p21.first_name as "team_2.player1.first_name",
+	p21.last_name as "team_2.player1.last_name",
+	p21.gender as "team_2.player1.gender",
+	m.team_2_player_2_id as "team_2.player2.id",
+	p22.first_name as "team_2.player2.first_name",
+	p22.last_name as "team_2.player2.last_name",
+	p22.gender as "team_2.player2.gender",
+	m.set_1_team_1 as "set_1.team_1",
+	m.set_1_team_2 as "set_1.team_2",
+	m.set_2_team_1 as "set_2.team_1",
+	m.set_2_team_2 as "set_2.team_2",
+	m.set_3_team_1 as "set_3.team_1",
+	m.set_3_team_2 as "set_3.team_2",
+	m.winner
+FROM matches m
+JOIN players p11 on p11.id = m.team_1_player_1_id
+JOIN players p12 on p12.id = m.team_1_player_2_id
+JOIN players p21 on p21.id = m.team_2_player_1_id
+JOIN players p22 on p22.id = m.team_2_player_2_id
+WHERE m.tournament_id = ? and m.deleted_at IS NULL
+ORDER BY m.match_nr
\ No newline at end of file
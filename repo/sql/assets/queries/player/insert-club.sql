@@ -0,0 +1,14 @@
+INSERT INTO player_clubs
+(
+	created_at,
+	player_id,
+	season,
+	club
+)
+VALUES
+(
+	:created_at,
+	:player_id,
+	:season,
+	:club
+)
\ No newline at end of file
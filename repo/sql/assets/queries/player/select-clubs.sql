@@ -0,0 +1,9 @@
+SELECT
+	c.created_at,
+	c.updated_at,
+	c.player_id,
+	c.season,
+	c.club
+FROM player_clubs c
+WHERE c.player_id = ? AND c.deleted_at IS NULL
+ORDER BY c.season DESC
\ No newline at end of file
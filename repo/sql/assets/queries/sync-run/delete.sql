@@ -0,0 +1,2 @@
+DELETE FROM sync_runs
+WHERE id = ?
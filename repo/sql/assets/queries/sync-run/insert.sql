@@ -0,0 +1,36 @@
+INSERT INTO sync_runs
+(
+	created_at,
+	type,
+	parameters,
+	start_date,
+	end_date,
+	duration_ms,
+	new_tournaments,
+	updated_tournaments,
+	deleted_tournaments,
+	new_teams,
+	updated_teams,
+	deleted_teams,
+	new_players,
+	updated_players,
+	error
+)
+VALUES
+(
+	:created_at,
+	:type,
+	:parameters,
+	:start_date,
+	:end_date,
+	:duration_ms,
+	:new_tournaments,
+	:updated_tournaments,
+	:deleted_tournaments,
+	:new_teams,
+	:updated_teams,
+	:deleted_teams,
+	:new_players,
+	:updated_players,
+	:error
+)
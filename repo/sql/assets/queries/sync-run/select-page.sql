@@ -0,0 +1,21 @@
+SELECT
+	r.id,
+	r.created_at,
+	r.updated_at,
+	r.type,
+	r.parameters,
+	r.start_date,
+	r.end_date,
+	r.duration_ms,
+	r.new_tournaments,
+	r.updated_tournaments,
+	r.deleted_tournaments,
+	r.new_teams,
+	r.updated_teams,
+	r.deleted_teams,
+	r.new_players,
+	r.updated_players,
+	r.error
+FROM sync_runs r
+ORDER BY r.start_date DESC, r.id DESC
+LIMIT ? OFFSET ?
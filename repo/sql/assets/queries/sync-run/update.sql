@@ -0,0 +1,14 @@
+UPDATE sync_runs SET
+	updated_at = :updated_at,
+	end_date = :end_date,
+	duration_ms = :duration_ms,
+	new_tournaments = :new_tournaments,
+	updated_tournaments = :updated_tournaments,
+	deleted_tournaments = :deleted_tournaments,
+	new_teams = :new_teams,
+	updated_teams = :updated_teams,
+	deleted_teams = :deleted_teams,
+	new_players = :new_players,
+	updated_players = :updated_players,
+	error = :error
+WHERE id = :id
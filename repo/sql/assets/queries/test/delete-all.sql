@@ -1,4 +1,13 @@
+DELETE FROM job_leases;
+DELETE FROM job_runs;
+DELETE FROM event_log;
+DELETE FROM webhook_deliveries;
+DELETE FROM webhooks;
+DELETE FROM tournament_changes;
+DELETE FROM sync_runs;
 DELETE FROM settings;
+DELETE FROM matches;
+DELETE FROM player_clubs;
 DELETE FROM tournament_teams;
 DELETE FROM users;
 DELETE FROM players;
@@ -0,0 +1,22 @@
+INSERT INTO tournament_changes
+(
+	created_at,
+	tournament_id,
+	team,
+	field,
+	old_value,
+	new_value,
+	sync_run_id,
+	changed_at
+)
+VALUES
+(
+	:created_at,
+	:tournament_id,
+	:team,
+	:field,
+	:old_value,
+	:new_value,
+	NULLIF(:sync_run_id, 0),
+	:changed_at
+)
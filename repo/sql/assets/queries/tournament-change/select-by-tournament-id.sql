@@ -0,0 +1,14 @@
+SELECT
+	c.id,
+	c.created_at,
+	c.updated_at,
+	c.tournament_id,
+	c.team,
+	c.field,
+	c.old_value,
+	c.new_value,
+	COALESCE(c.sync_run_id, 0) AS sync_run_id,
+	c.changed_at
+FROM tournament_changes c
+WHERE c.tournament_id = ?
+ORDER BY c.changed_at DESC, c.id DESC
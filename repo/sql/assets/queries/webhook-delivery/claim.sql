@@ -0,0 +1,5 @@
+UPDATE webhook_deliveries SET
+	updated_at = ?,
+	status = 'sending',
+	next_attempt = ?
+WHERE id = ? AND status IN ('pending', 'sending') AND next_attempt <= ?
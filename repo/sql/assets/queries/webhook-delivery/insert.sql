@@ -0,0 +1,28 @@
+INSERT INTO webhook_deliveries
+(
+	created_at,
+	webhook_id,
+	event_id,
+	event_name,
+	payload,
+	status,
+	attempts,
+	next_attempt,
+	response_status,
+	last_error,
+	delivered_at
+)
+VALUES
+(
+	:created_at,
+	:webhook_id,
+	:event_id,
+	:event_name,
+	:payload,
+	:status,
+	:attempts,
+	:next_attempt,
+	:response_status,
+	:last_error,
+	:delivered_at
+)
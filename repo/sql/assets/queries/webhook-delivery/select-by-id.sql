@@ -0,0 +1,16 @@
+SELECT
+	d.id,
+	d.created_at,
+	d.updated_at,
+	d.webhook_id,
+	d.event_id,
+	d.event_name,
+	d.payload,
+	d.status,
+	d.attempts,
+	d.next_attempt,
+	d.response_status,
+	d.last_error,
+	d.delivered_at
+FROM webhook_deliveries d
+WHERE d.id = ?
@@ -0,0 +1 @@
+SELECT COALESCE(MAX(event_id), 0) FROM webhook_deliveries
@@ -0,0 +1,2 @@
+SELECT d.webhook_id FROM webhook_deliveries d
+WHERE d.event_id = ?
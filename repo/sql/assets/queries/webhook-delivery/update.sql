@@ -0,0 +1,9 @@
+UPDATE webhook_deliveries SET
+	updated_at = :updated_at,
+	status = :status,
+	attempts = :attempts,
+	next_attempt = :next_attempt,
+	response_status = :response_status,
+	last_error = :last_error,
+	delivered_at = :delivered_at
+WHERE id = :id
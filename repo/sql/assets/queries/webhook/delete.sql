@@ -0,0 +1,3 @@
+UPDATE webhooks SET
+	deleted_at = :deleted_at
+WHERE id = :id AND deleted_at IS NULL
@@ -0,0 +1,14 @@
+INSERT INTO webhooks
+(
+	created_at,
+	url,
+	events,
+	secret
+)
+VALUES
+(
+	:created_at,
+	:url,
+	:events,
+	:secret
+)
@@ -0,0 +1,10 @@
+SELECT
+	w.id,
+	w.created_at,
+	w.updated_at,
+	w.url,
+	w.events,
+	w.secret
+FROM webhooks w
+WHERE w.deleted_at IS NULL
+ORDER BY w.id
@@ -0,0 +1,9 @@
+SELECT
+	w.id,
+	w.created_at,
+	w.updated_at,
+	w.url,
+	w.events,
+	w.secret
+FROM webhooks w
+WHERE w.id = ? AND w.deleted_at IS NULL
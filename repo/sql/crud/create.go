@@ -3,12 +3,11 @@ package crud
 import (
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/raphi011/scores-api"
 )
 
 // Create creates a new entity.
-func Create(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
+func Create(db DB, queryName string, entities ...scores.Tracked) error {
 	var err error
 
 	stmt, err := db.PrepareNamed(namedQuery(db, queryName))
@@ -33,7 +32,7 @@ func Create(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
 }
 
 // CreateSetID creates a new entity and sets the newly assigned primary key.
-func CreateSetID(db *sqlx.DB, queryName string, entities ...scores.Model) error {
+func CreateSetID(db DB, queryName string, entities ...scores.Model) error {
 	var err error
 
 	if db.DriverName() == "postgres" {
@@ -45,7 +44,7 @@ func CreateSetID(db *sqlx.DB, queryName string, entities ...scores.Model) error
 	return mapError(err)
 }
 
-func createResultID(db *sqlx.DB, queryName string, entities ...scores.Model) error {
+func createResultID(db DB, queryName string, entities ...scores.Model) error {
 	q := query(db, queryName)
 
 	stmt, err := db.PrepareNamed(q)
@@ -76,7 +75,7 @@ func createResultID(db *sqlx.DB, queryName string, entities ...scores.Model) err
 	return nil
 }
 
-func createQueryID(db *sqlx.DB, queryName string, entities ...scores.Model) error {
+func createQueryID(db DB, queryName string, entities ...scores.Model) error {
 	q := namedQuery(db, queryName)
 
 	stmt, err := db.PrepareNamed(q)
@@ -89,19 +88,11 @@ func createQueryID(db *sqlx.DB, queryName string, entities ...scores.Model) erro
 
 	for _, entity := range entities {
 		setTrackedCreate(entity, now)
-		rows, err := stmt.Query(entity)
-
-		if err != nil {
-			return err
-		}
-
-		defer rows.Close()
-
 		var id int
 
-		if rows.Next() {
-			err = rows.Scan(&id)
-		}
+		// the row must be read before the next query can be
+		// sent on the same connection, e.g. in a transaction
+		err := stmt.QueryRow(entity).Scan(&id)
 
 		if err != nil {
 			return err
@@ -3,12 +3,11 @@ package crud
 import (
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/raphi011/scores-api"
 )
 
 // Delete deletes an entity.
-func Delete(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
+func Delete(db DB, queryName string, entities ...scores.Tracked) error {
 	stmt, err := db.PrepareNamed(namedQuery(db, queryName))
 
 	if err != nil {
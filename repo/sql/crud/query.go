@@ -9,14 +9,43 @@ import (
 	"github.com/raphi011/scores-api/repo/sql/assets"
 )
 
+// DB is implemented by both `*sqlx.DB` and `*sqlx.Tx`, this allows
+// to run the queries within a transaction.
+type DB interface {
+	sqlx.Ext
+
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	PrepareNamed(query string) (*sqlx.NamedStmt, error)
+}
+
+var (
+	_ DB = &sqlx.DB{}
+	_ DB = &sqlx.Tx{}
+)
+
 // Execute executes a query.
-func Execute(db *sqlx.DB, queryName string) error {
+func Execute(db DB, queryName string) error {
 	_, err := db.Exec(loadQuery(db, queryName))
 
 	return err
 }
 
-func loadQuery(db *sqlx.DB, name string) string {
+// ExecuteArgs executes a query with the positional `args`
+// and returns the number of affected rows.
+func ExecuteArgs(db DB, queryName string, args ...interface{}) (int64, error) {
+	result, err := db.Exec(query(db, queryName), args...)
+
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	return rowsAffected, mapError(err)
+}
+
+func loadQuery(db DB, name string) string {
 	var q string
 	var err error
 
@@ -32,11 +61,11 @@ func loadQuery(db *sqlx.DB, name string) string {
 	panic(fmt.Sprintf("could not load sql query %s: %v", name, err))
 }
 
-func namedQuery(db *sqlx.DB, name string) string {
+func namedQuery(db DB, name string) string {
 	return loadQuery(db, name)
 }
 
-func query(db *sqlx.DB, queryName string) string {
+func query(db DB, queryName string) string {
 	return db.Rebind(loadQuery(db, queryName))
 }
 
@@ -6,7 +6,7 @@ import (
 )
 
 // ReadIn reads rows into `dest` and expands the query's `IN` parameters.
-func ReadIn(db *sqlx.DB, queryName string, dest interface{}, args ...interface{}) error {
+func ReadIn(db DB, queryName string, dest interface{}, args ...interface{}) error {
 	q, args, err := sqlx.In(loadQuery(db, queryName), args...)
 
 	if err != nil {
@@ -21,7 +21,7 @@ func ReadIn(db *sqlx.DB, queryName string, dest interface{}, args ...interface{}
 }
 
 // ReadNamed reads rows into `dest` via a named query struct.
-func ReadNamed(db *sqlx.DB, queryName string, dest interface{}, arg interface{}) error {
+func ReadNamed(db DB, queryName string, dest interface{}, arg interface{}) error {
 	stmt, err := db.PrepareNamed(namedQuery(db, queryName))
 
 	if err != nil {
@@ -34,7 +34,7 @@ func ReadNamed(db *sqlx.DB, queryName string, dest interface{}, arg interface{})
 }
 
 // Read reads rows into `dest`.
-func Read(db *sqlx.DB, queryName string, dest interface{}, args ...interface{}) error {
+func Read(db DB, queryName string, dest interface{}, args ...interface{}) error {
 	q := query(db, queryName)
 
 	err := db.Select(dest, q, args...)
@@ -43,7 +43,7 @@ func Read(db *sqlx.DB, queryName string, dest interface{}, args ...interface{})
 }
 
 // ReadOne reads  one row into `dest`.
-func ReadOne(db *sqlx.DB, queryName string, dest interface{}, args ...interface{}) error {
+func ReadOne(db DB, queryName string, dest interface{}, args ...interface{}) error {
 	q := query(db, queryName)
 
 	err := db.Get(dest, q, args...)
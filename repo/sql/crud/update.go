@@ -3,13 +3,11 @@ package crud
 import (
 	"time"
 
-	"github.com/jmoiron/sqlx"
-
 	"github.com/raphi011/scores-api"
 )
 
 // Update updates multiple entities and updates the `UpdatedAt` field.
-func Update(db *sqlx.DB, queryName string, entities ...scores.Tracked) error {
+func Update(db DB, queryName string, entities ...scores.Tracked) error {
 	stmt, err := db.PrepareNamed(namedQuery(db, queryName))
 
 	if err != nil {
@@ -0,0 +1,41 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
+)
+
+var _ repo.EventLogRepository = &eventLogRepository{}
+
+type eventLogRepository struct {
+	DB crud.DB
+}
+
+// New persists an event and assigns its sequence number.
+func (s *eventLogRepository) New(e *scores.EventLogEntry) (*scores.EventLogEntry, error) {
+	err := crud.CreateSetID(s.DB, "event-log/insert", e)
+
+	return e, errors.Wrap(err, "insert event")
+}
+
+// Since loads up to `limit` events with a sequence number greater than `id`.
+func (s *eventLogRepository) Since(id, limit int) ([]*scores.EventLogEntry, error) {
+	entries := []*scores.EventLogEntry{}
+	err := crud.Read(s.DB, "event-log/select-since", &entries, id, limit)
+
+	return entries, errors.Wrap(err, "events since")
+}
+
+// From loads up to `limit` events that were published at or after `from`
+// and have a sequence number greater than `id`.
+func (s *eventLogRepository) From(from time.Time, id, limit int) ([]*scores.EventLogEntry, error) {
+	entries := []*scores.EventLogEntry{}
+	err := crud.Read(s.DB, "event-log/select-from", &entries, from, id, limit)
+
+	return entries, errors.Wrap(err, "events from")
+}
@@ -0,0 +1,37 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestEventLog(t *testing.T) {
+	db := SetupDB(t)
+	eventLogRepo := &eventLogRepository{DB: db}
+
+	start := time.Now()
+
+	first, err := eventLogRepo.New(&scores.EventLogEntry{Name: "volleynet/tournament/created", Body: "{}"})
+	test.Check(t, "eventLogRepository.New(), err: %v", err)
+
+	second, err := eventLogRepo.New(&scores.EventLogEntry{Name: "volleynet/tournament/canceled", Body: "{}"})
+	test.Check(t, "eventLogRepository.New(), err: %v", err)
+	test.Assert(t, "eventLogRepository.New(), want increasing ids, got: %d, %d", second.ID > first.ID, first.ID, second.ID)
+
+	entries, err := eventLogRepo.Since(first.ID, 10)
+	test.Check(t, "eventLogRepository.Since(), err: %v", err)
+	test.Assert(t, "eventLogRepository.Since(), want 1 event, got: %d", len(entries) == 1, len(entries))
+
+	entries, err = eventLogRepo.From(start.Add(-time.Minute), 0, 10)
+	test.Check(t, "eventLogRepository.From(), err: %v", err)
+	test.Assert(t, "eventLogRepository.From(), want 2 events, got: %d", len(entries) == 2, len(entries))
+
+	entries, err = eventLogRepo.From(start.Add(time.Hour), 0, 10)
+	test.Check(t, "eventLogRepository.From(), err: %v", err)
+	test.Assert(t, "eventLogRepository.From(), want 0 events, got: %d", len(entries) == 0, len(entries))
+}
@@ -0,0 +1,33 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
+)
+
+var _ repo.JobLeaseRepository = &jobLeaseRepository{}
+
+type jobLeaseRepository struct {
+	DB crud.DB
+}
+
+// Acquire grants `holder` the lease on a job for `ttl` if nobody else holds
+// an unexpired lease on it, a lease of `holder` is renewed. The expiry is
+// based on the database's clock so that the clocks of the instances don't
+// need to be in sync.
+func (s *jobLeaseRepository) Acquire(jobName, holder string, ttl time.Duration) (bool, error) {
+	rowsAffected, err := crud.ExecuteArgs(s.DB, "job-lease/acquire", jobName, holder, ttl.Seconds())
+
+	return rowsAffected == 1, errors.Wrap(err, "acquire job lease")
+}
+
+// Release gives up the lease of `holder` on a job.
+func (s *jobLeaseRepository) Release(jobName, holder string) error {
+	_, err := crud.ExecuteArgs(s.DB, "job-lease/release", jobName, holder)
+
+	return errors.Wrap(err, "release job lease")
+}
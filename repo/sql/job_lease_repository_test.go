@@ -0,0 +1,43 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestJobLeases(t *testing.T) {
+	db := SetupDB(t)
+	jobLeaseRepo := &jobLeaseRepository{DB: db}
+
+	acquired, err := jobLeaseRepo.Acquire("Tournaments", "a", time.Minute)
+	test.Check(t, "jobLeaseRepository.Acquire(), err: %v", err)
+	test.Assert(t, "jobLeaseRepository.Acquire(), want the free lease to be acquired", acquired)
+
+	acquired, err = jobLeaseRepo.Acquire("Tournaments", "b", time.Minute)
+	test.Check(t, "jobLeaseRepository.Acquire(), err: %v", err)
+	test.Assert(t, "jobLeaseRepository.Acquire(), want the lease of another holder not to be acquired", !acquired)
+
+	// a lease renewed with a negative ttl has already expired
+	acquired, err = jobLeaseRepo.Acquire("Tournaments", "a", -time.Second)
+	test.Check(t, "jobLeaseRepository.Acquire(), err: %v", err)
+	test.Assert(t, "jobLeaseRepository.Acquire(), want the holder to renew its lease", acquired)
+
+	acquired, err = jobLeaseRepo.Acquire("Tournaments", "b", time.Minute)
+	test.Check(t, "jobLeaseRepository.Acquire(), err: %v", err)
+	test.Assert(t, "jobLeaseRepository.Acquire(), want the expired lease to be acquired", acquired)
+
+	acquired, err = jobLeaseRepo.Acquire("Tournaments", "a", time.Minute)
+	test.Check(t, "jobLeaseRepository.Acquire(), err: %v", err)
+	test.Assert(t, "jobLeaseRepository.Acquire(), want the lease of another holder not to be acquired", !acquired)
+
+	err = jobLeaseRepo.Release("Tournaments", "b")
+	test.Check(t, "jobLeaseRepository.Release(), err: %v", err)
+
+	acquired, err = jobLeaseRepo.Acquire("Tournaments", "a", time.Minute)
+	test.Check(t, "jobLeaseRepository.Acquire(), err: %v", err)
+	test.Assert(t, "jobLeaseRepository.Acquire(), want the released lease to be acquired", acquired)
+}
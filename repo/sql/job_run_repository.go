@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
+)
+
+var _ repo.JobRunRepository = &jobRunRepository{}
+
+type jobRunRepository struct {
+	DB crud.DB
+}
+
+// New persists a job run and assigns a new id.
+func (s *jobRunRepository) New(r *scores.JobRun) (*scores.JobRun, error) {
+	err := crud.CreateSetID(s.DB, "job-run/insert", r)
+
+	return r, errors.Wrap(err, "insert job run")
+}
+
+// Get loads a job run with its log.
+func (s *jobRunRepository) Get(id int) (*scores.JobRun, error) {
+	run := &scores.JobRun{}
+	err := crud.ReadOne(s.DB, "job-run/select-by-id", run, id)
+
+	return run, errors.Wrap(err, "get job run")
+}
+
+// Page loads `limit` runs of the job `jobName` (or of all jobs if it is
+// empty) without their logs starting at `offset`, the latest run first.
+func (s *jobRunRepository) Page(jobName string, offset, limit int) ([]*scores.JobRun, error) {
+	runs := []*scores.JobRun{}
+	err := crud.Read(s.DB, "job-run/select-page", &runs, jobName, jobName, limit, offset)
+
+	return runs, errors.Wrap(err, "page job runs")
+}
+
+// Count returns the number of runs of the job `jobName`, or of all jobs if it is empty.
+func (s *jobRunRepository) Count(jobName string) (int, error) {
+	count := 0
+	err := crud.ReadOne(s.DB, "job-run/count", &count, jobName, jobName)
+
+	return count, errors.Wrap(err, "count job runs")
+}
@@ -0,0 +1,47 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestJobRuns(t *testing.T) {
+	db := SetupDB(t)
+	jobRunRepo := &jobRunRepository{DB: db}
+
+	start := time.Now().Add(-time.Hour)
+
+	first, err := jobRunRepo.New(&scores.JobRun{
+		Job:     "Tournaments",
+		Start:   start,
+		End:     start.Add(time.Minute),
+		Outcome: "failed",
+		Error:   "volleynet is down",
+		Log:     "job \"Tournaments\" failed: volleynet is down\n",
+	})
+	test.Check(t, "jobRunRepository.New(), err: %v", err)
+
+	_, err = jobRunRepo.New(&scores.JobRun{Job: "Tournaments", Start: start.Add(time.Minute), End: start.Add(2 * time.Minute), Outcome: "succeeded"})
+	test.Check(t, "jobRunRepository.New(), err: %v", err)
+
+	_, err = jobRunRepo.New(&scores.JobRun{Job: "Players", Start: start, End: start, Outcome: "succeeded"})
+	test.Check(t, "jobRunRepository.New(), err: %v", err)
+
+	runs, err := jobRunRepo.Page("Tournaments", 0, 10)
+	test.Check(t, "jobRunRepository.Page(), err: %v", err)
+	test.Assert(t, "jobRunRepository.Page(), want 2 runs, got: %d", len(runs) == 2, len(runs))
+	test.Assert(t, "jobRunRepository.Page(), want the latest run first", runs[1].ID == first.ID)
+
+	count, err := jobRunRepo.Count("")
+	test.Check(t, "jobRunRepository.Count(), err: %v", err)
+	test.Equal(t, "jobRunRepository.Count(), want %d runs, got: %d", 3, count)
+
+	run, err := jobRunRepo.Get(first.ID)
+	test.Check(t, "jobRunRepository.Get(), err: %v", err)
+	test.Equal(t, "jobRunRepository.Get(), want log %q, got: %q", first.Log, run.Log)
+}
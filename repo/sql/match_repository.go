@@ -0,0 +1,46 @@
+package sql
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+var _ repo.MatchRepository = &matchRepository{}
+
+type matchRepository struct {
+	DB crud.DB
+}
+
+// New creates a new match.
+func (s *matchRepository) New(m *volleynet.Match) (*volleynet.Match, error) {
+	err := crud.Create(s.DB, "match/insert", m)
+
+	return m, errors.Wrap(err, "insert match")
+}
+
+// NewBatch creates new matches.
+func (s *matchRepository) NewBatch(matches ...*volleynet.Match) error {
+	ms := make([]scores.Tracked, len(matches))
+
+	for i, m := range matches {
+		ms[i] = m
+	}
+
+	err := crud.Create(s.DB, "match/insert", ms...)
+
+	return errors.Wrap(err, "batch insert match")
+}
+
+// ByTournament loads all matches of a tournament.
+func (s *matchRepository) ByTournament(tournamentID int) (
+	[]*volleynet.Match, error) {
+
+	matches := []*volleynet.Match{}
+	err := crud.Read(s.DB, "match/select-by-tournament-id", &matches, tournamentID)
+
+	return matches, errors.Wrap(err, "byTournament match")
+}
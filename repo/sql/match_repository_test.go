@@ -0,0 +1,89 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func TestCreateMatch(t *testing.T) {
+	db := SetupDB(t)
+	matchRepo := &matchRepository{DB: db}
+
+	ps := CreatePlayers(t, db,
+		P{Gender: "M", ID: 1},
+		P{Gender: "M", ID: 2},
+		P{Gender: "M", ID: 3},
+		P{Gender: "M", ID: 4},
+	)
+
+	ts := CreateTournaments(t, db,
+		T{ID: 1},
+	)
+
+	_, err := matchRepo.New(&volleynet.Match{
+		TournamentID: ts[0].ID,
+		MatchNr:      1,
+		Team1:        &volleynet.MatchTeam{Player1: ps[0], Player2: ps[1]},
+		Team2:        &volleynet.MatchTeam{Player1: ps[2], Player2: ps[3]},
+		Set1:         volleynet.MatchSet{Team1: 21, Team2: 15},
+		Set2:         volleynet.MatchSet{Team1: 21, Team2: 18},
+		Winner:       1,
+	})
+
+	test.Check(t, "matchRepository.New(), err: %v", err)
+}
+
+func TestTournamentMatches(t *testing.T) {
+	db := SetupDB(t)
+	matchRepo := &matchRepository{DB: db}
+
+	ps := CreatePlayers(t, db,
+		P{Gender: "M", ID: 1},
+		P{Gender: "M", ID: 2},
+		P{Gender: "M", ID: 3},
+		P{Gender: "M", ID: 4},
+	)
+
+	ts := CreateTournaments(t, db,
+		T{ID: 1},
+		T{ID: 2},
+	)
+
+	err := matchRepo.NewBatch(
+		&volleynet.Match{
+			TournamentID: ts[0].ID,
+			MatchNr:      1,
+			Team1:        &volleynet.MatchTeam{Player1: ps[0], Player2: ps[1]},
+			Team2:        &volleynet.MatchTeam{Player1: ps[2], Player2: ps[3]},
+			Set1:         volleynet.MatchSet{Team1: 15, Team2: 21},
+			Set2:         volleynet.MatchSet{Team1: 19, Team2: 21},
+			Winner:       2,
+		},
+		&volleynet.Match{
+			TournamentID: ts[0].ID,
+			MatchNr:      2,
+			Team1:        &volleynet.MatchTeam{Player1: ps[0], Player2: ps[1]},
+			Team2:        &volleynet.MatchTeam{Player1: ps[2], Player2: ps[3]},
+		},
+		&volleynet.Match{
+			TournamentID: ts[1].ID,
+			MatchNr:      1,
+			Team1:        &volleynet.MatchTeam{Player1: ps[0], Player2: ps[1]},
+			Team2:        &volleynet.MatchTeam{Player1: ps[2], Player2: ps[3]},
+		},
+	)
+
+	test.Check(t, "matchRepository.NewBatch(), err: %v", err)
+
+	matches, err := matchRepo.ByTournament(ts[0].ID)
+
+	test.Check(t, "matchRepository.ByTournament(), err: %v", err)
+	test.Assert(t, "matchRepository.ByTournament(), want len(matches) == 2, got: %d", len(matches) == 2, len(matches))
+	test.Assert(t, "match winner should be %d, got: %d", matches[0].Winner == 2, 2, matches[0].Winner)
+	test.Assert(t, "match set 2 should be 19:21, got: %+v", matches[0].Set2.Team1 == 19 && matches[0].Set2.Team2 == 21, matches[0].Set2)
+	test.Assert(t, "match team 2 player 1 should be %d, got: %d", matches[0].Team2.Player1.ID == ps[2].ID, ps[2].ID, matches[0].Team2.Player1.ID)
+}
@@ -1,16 +1,16 @@
 package sql
 
 import (
-	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
+	"github.com/raphi011/scores-api"
 	"github.com/raphi011/scores-api/repo"
 	"github.com/raphi011/scores-api/repo/sql/crud"
 	"github.com/raphi011/scores-api/volleynet"
 )
 
 type playerRepository struct {
-	DB *sqlx.DB
+	DB crud.DB
 }
 
 var _ repo.PlayerRepository = &playerRepository{}
@@ -77,6 +77,27 @@ func (s *playerRepository) Search(filter repo.PlayerFilter) ([]*volleynet.Player
 	return players, errors.Wrap(err, "search")
 }
 
+// Clubs returns the club history of a player.
+func (s *playerRepository) Clubs(playerID int) ([]*volleynet.PlayerClub, error) {
+	clubs := []*volleynet.PlayerClub{}
+	err := crud.Read(s.DB, "player/select-clubs", &clubs, playerID)
+
+	return clubs, errors.Wrap(err, "clubs")
+}
+
+// NewClubs adds entries to the club history of players.
+func (s *playerRepository) NewClubs(clubs ...*volleynet.PlayerClub) error {
+	cs := make([]scores.Tracked, len(clubs))
+
+	for i, c := range clubs {
+		cs[i] = c
+	}
+
+	err := crud.Create(s.DB, "player/insert-club", cs...)
+
+	return errors.Wrap(err, "new clubs")
+}
+
 func startsWith(query string) string {
 	if len(query) == 0 {
 		return query
@@ -112,3 +112,25 @@ func TestSearchPlayers(t *testing.T) {
 	test.Check(t, "playerRepo.Search() failed: %v", err)
 	test.Assert(t, "len(Search) should be 2 but is %d", len(players) == 2, len(players))
 }
+
+func TestPlayerClubs(t *testing.T) {
+	db := SetupDB(t)
+	playerRepo := &playerRepository{DB: db}
+
+	CreatePlayers(t, db,
+		P{ID: 1},
+	)
+
+	err := playerRepo.NewClubs(
+		&volleynet.PlayerClub{PlayerID: 1, Season: "2017", Club: "Club A"},
+		&volleynet.PlayerClub{PlayerID: 1, Season: "2018", Club: "Club B"},
+	)
+
+	test.Check(t, "playerRepository.NewClubs(), err: %v", err)
+
+	clubs, err := playerRepo.Clubs(1)
+
+	test.Check(t, "playerRepository.Clubs(), err: %v", err)
+	test.Assert(t, "playerRepository.Clubs(), want len(clubs) == 2, got: %d", len(clubs) == 2, len(clubs))
+	test.Assert(t, "playerRepository.Clubs(), want latest season first, got: %s", clubs[0].Season == "2018", clubs[0].Season)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
 	"github.com/raphi011/scores-api/repo/sql/migrate"
 )
 
@@ -18,11 +19,27 @@ func Repositories(provider, connectionString string) (*repo.Repositories, error)
 
 	err = migrate.All(provider, db)
 
+	repos := repositories(db)
+	repos.UnitOfWork = &unitOfWork{DB: db}
+
+	return repos, err
+}
+
+// repositories returns all repositories that run their queries on `db`.
+func repositories(db crud.DB) *repo.Repositories {
 	return &repo.Repositories{
 		UserRepo:       &userRepository{DB: db},
+		MatchRepo:      &matchRepository{DB: db},
 		PlayerRepo:     &playerRepository{DB: db},
 		TournamentRepo: &tournamentRepository{DB: db},
 		TeamRepo:       &teamRepository{DB: db},
 		SettingRepo:    &settingRepository{DB: db},
-	}, err
+		SyncRunRepo:    &syncRunRepository{DB: db},
+		ChangeRepo:     &tournamentChangeRepository{DB: db},
+		WebhookRepo:    &webhookRepository{DB: db},
+		DeliveryRepo:   &webhookDeliveryRepository{DB: db},
+		EventLogRepo:   &eventLogRepository{DB: db},
+		JobRunRepo:     &jobRunRepository{DB: db},
+		JobLeaseRepo:   &jobLeaseRepository{DB: db},
+	}
 }
@@ -2,7 +2,6 @@ package sql
 
 import (
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
 	"github.com/raphi011/scores-api"
@@ -13,7 +12,7 @@ import (
 var _ repo.SettingRepository = &settingRepository{}
 
 type settingRepository struct {
-	DB *sqlx.DB
+	DB crud.DB
 }
 
 func (s *settingRepository) Create(setting *scores.Setting) (*scores.Setting, error) {
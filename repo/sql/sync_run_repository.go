@@ -0,0 +1,52 @@
+package sql
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+var _ repo.SyncRunRepository = &syncRunRepository{}
+
+type syncRunRepository struct {
+	DB crud.DB
+}
+
+// New persists a sync run and assigns a new id.
+func (s *syncRunRepository) New(r *volleynet.SyncRun) (*volleynet.SyncRun, error) {
+	err := crud.CreateSetID(s.DB, "sync-run/insert", r)
+
+	return r, errors.Wrap(err, "insert sync run")
+}
+
+// Update updates a sync run.
+func (s *syncRunRepository) Update(r *volleynet.SyncRun) error {
+	err := crud.Update(s.DB, "sync-run/update", r)
+
+	return errors.Wrap(err, "update sync run")
+}
+
+// Delete removes a sync run.
+func (s *syncRunRepository) Delete(r *volleynet.SyncRun) error {
+	_, err := crud.ExecuteArgs(s.DB, "sync-run/delete", r.ID)
+
+	return errors.Wrap(err, "delete sync run")
+}
+
+// Page loads `limit` sync runs starting at `offset`, the latest run first.
+func (s *syncRunRepository) Page(offset, limit int) ([]*volleynet.SyncRun, error) {
+	runs := []*volleynet.SyncRun{}
+	err := crud.Read(s.DB, "sync-run/select-page", &runs, limit, offset)
+
+	return runs, errors.Wrap(err, "page sync runs")
+}
+
+// Count returns the number of persisted sync runs.
+func (s *syncRunRepository) Count() (int, error) {
+	count := 0
+	err := crud.ReadOne(s.DB, "sync-run/count", &count)
+
+	return count, errors.Wrap(err, "count sync runs")
+}
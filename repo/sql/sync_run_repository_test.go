@@ -0,0 +1,72 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func TestCreateSyncRun(t *testing.T) {
+	db := SetupDB(t)
+	syncRunRepo := &syncRunRepository{DB: db}
+
+	run, err := syncRunRepo.New(&volleynet.SyncRun{
+		Type:           "tournaments",
+		Parameters:     "gender=M league=AMATEUR TOUR season=2018",
+		Start:          time.Now(),
+		NewTournaments: 2,
+	})
+
+	test.Check(t, "syncRunRepository.New(), err: %v", err)
+	test.Assert(t, "syncRunRepository.New(), want ID != 0, got 0", run.ID != 0)
+
+	runs, err := syncRunRepo.Page(0, 1)
+	test.Check(t, "syncRunRepository.Page(), err: %v", err)
+	test.Assert(t, "syncRunRepository.New(), want no .End while the run is in progress, got: %v", runs[0].End == nil, runs[0].End)
+
+	end := time.Now()
+	run.End = &end
+	run.Error = "timeout"
+
+	err = syncRunRepo.Update(run)
+	test.Check(t, "syncRunRepository.Update(), err: %v", err)
+
+	runs, err = syncRunRepo.Page(0, 1)
+	test.Check(t, "syncRunRepository.Page(), err: %v", err)
+	test.Equal(t, "syncRunRepository.Update(), want .Error: %q, got: %q", "timeout", runs[0].Error)
+	test.Assert(t, "syncRunRepository.Update(), want .End, got none", runs[0].End != nil)
+
+	err = syncRunRepo.Delete(run)
+	test.Check(t, "syncRunRepository.Delete(), err: %v", err)
+
+	count, err := syncRunRepo.Count()
+	test.Check(t, "syncRunRepository.Count(), err: %v", err)
+	test.Assert(t, "syncRunRepository.Delete(), want 0 runs, got: %d", count == 0, count)
+}
+
+func TestPageSyncRuns(t *testing.T) {
+	db := SetupDB(t)
+	syncRunRepo := &syncRunRepository{DB: db}
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		_, err := syncRunRepo.New(&volleynet.SyncRun{
+			Type:  "ladder",
+			Start: start.Add(time.Duration(i) * time.Minute),
+		})
+		test.Check(t, "syncRunRepository.New(), err: %v", err)
+	}
+
+	runs, err := syncRunRepo.Page(1, 5)
+	test.Check(t, "syncRunRepository.Page(), err: %v", err)
+	test.Assert(t, "syncRunRepository.Page(), want 2 runs, got: %d", len(runs) == 2, len(runs))
+	test.Assert(t, "syncRunRepository.Page(), want latest runs first", runs[0].Start.After(runs[1].Start))
+
+	count, err := syncRunRepo.Count()
+	test.Check(t, "syncRunRepository.Count(), err: %v", err)
+	test.Assert(t, "syncRunRepository.Count(), want 3, got: %d", count == 3, count)
+}
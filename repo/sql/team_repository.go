@@ -1,7 +1,6 @@
 package sql
 
 import (
-	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
 	"github.com/raphi011/scores-api"
@@ -13,7 +12,7 @@ import (
 var _ repo.TeamRepository = &teamRepository{}
 
 type teamRepository struct {
-	DB *sqlx.DB
+	DB crud.DB
 }
 
 // New creates a new team.
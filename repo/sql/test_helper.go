@@ -23,13 +23,10 @@ func RepositoriesTest(t testing.TB) (*repo.Repositories, *sqlx.DB) {
 
 	db := SetupDB(t)
 
-	return &repo.Repositories{
-		UserRepo:       &userRepository{DB: db},
-		PlayerRepo:     &playerRepository{DB: db},
-		TournamentRepo: &tournamentRepository{DB: db},
-		TeamRepo:       &teamRepository{DB: db},
-		SettingRepo:    &settingRepository{DB: db},
-	}, db
+	repos := repositories(db)
+	repos.UnitOfWork = &unitOfWork{DB: db}
+
+	return repos, db
 }
 
 // SetupDB sets up a database connection, runs all migrations and
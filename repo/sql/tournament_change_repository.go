@@ -0,0 +1,37 @@
+package sql
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+var _ repo.TournamentChangeRepository = &tournamentChangeRepository{}
+
+type tournamentChangeRepository struct {
+	DB crud.DB
+}
+
+// NewBatch creates new tournament changes.
+func (s *tournamentChangeRepository) NewBatch(changes ...*volleynet.TournamentChange) error {
+	cs := make([]scores.Tracked, len(changes))
+
+	for i, c := range changes {
+		cs[i] = c
+	}
+
+	err := crud.Create(s.DB, "tournament-change/insert", cs...)
+
+	return errors.Wrap(err, "batch insert tournament change")
+}
+
+// ByTournament loads all changes of a tournament, the latest change first.
+func (s *tournamentChangeRepository) ByTournament(tournamentID int) ([]*volleynet.TournamentChange, error) {
+	changes := []*volleynet.TournamentChange{}
+	err := crud.Read(s.DB, "tournament-change/select-by-tournament-id", &changes, tournamentID)
+
+	return changes, errors.Wrap(err, "byTournament tournament change")
+}
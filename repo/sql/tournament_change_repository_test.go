@@ -0,0 +1,31 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func TestTournamentChanges(t *testing.T) {
+	db := SetupDB(t)
+	changeRepo := &tournamentChangeRepository{DB: db}
+
+	CreateTournaments(t, db, T{ID: 1})
+
+	now := time.Now()
+
+	err := changeRepo.NewBatch(
+		&volleynet.TournamentChange{TournamentID: 1, Field: "Location", OldValue: "Wien", NewValue: "Graz", ChangedAt: now.Add(-time.Hour)},
+		&volleynet.TournamentChange{TournamentID: 1, Field: "MaxTeams", OldValue: "16", NewValue: "24", ChangedAt: now},
+	)
+	test.Check(t, "tournamentChangeRepository.NewBatch(), err: %v", err)
+
+	changes, err := changeRepo.ByTournament(1)
+	test.Check(t, "tournamentChangeRepository.ByTournament(), err: %v", err)
+	test.Assert(t, "tournamentChangeRepository.ByTournament(), want 2 changes, got: %d", len(changes) == 2, len(changes))
+	test.Equal(t, "tournamentChangeRepository.ByTournament(), want latest change .Field: %q, got: %q", "MaxTeams", changes[0].Field)
+}
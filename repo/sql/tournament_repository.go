@@ -3,7 +3,6 @@ package sql
 import (
 	"sort"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
 	"github.com/raphi011/scores-api"
@@ -13,7 +12,7 @@ import (
 )
 
 type tournamentRepository struct {
-	DB *sqlx.DB
+	DB crud.DB
 }
 
 var _ repo.TournamentRepository = &tournamentRepository{}
@@ -0,0 +1,42 @@
+package sql
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/repo"
+)
+
+var _ repo.UnitOfWork = &unitOfWork{}
+
+type unitOfWork struct {
+	DB *sqlx.DB
+}
+
+// Do runs `work` with repositories that share a single transaction.
+func (u *unitOfWork) Do(work func(repos *repo.Repositories) error) error {
+	tx, err := u.DB.Beginx()
+
+	if err != nil {
+		return errors.Wrap(err, "begin transaction")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = work(repositories(tx))
+
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Wrapf(err, "rollback failed: %v", rollbackErr)
+		}
+
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "commit transaction")
+}
@@ -0,0 +1,46 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func TestUnitOfWorkCommit(t *testing.T) {
+	repos, _ := RepositoriesTest(t)
+
+	err := repos.UnitOfWork.Do(func(tx *repo.Repositories) error {
+		_, err := tx.PlayerRepo.New(&volleynet.Player{ID: 1})
+
+		return err
+	})
+
+	test.Check(t, "unitOfWork.Do(), err: %v", err)
+
+	_, err = repos.PlayerRepo.Get(1)
+	test.Check(t, "playerRepo.Get(), err: %v", err)
+}
+
+func TestUnitOfWorkRollback(t *testing.T) {
+	repos, _ := RepositoriesTest(t)
+	workErr := errors.New("sync failed")
+
+	err := repos.UnitOfWork.Do(func(tx *repo.Repositories) error {
+		_, err := tx.PlayerRepo.New(&volleynet.Player{ID: 1})
+		test.Check(t, "playerRepo.New(), err: %v", err)
+
+		return workErr
+	})
+
+	test.Assert(t, "unitOfWork.Do(), want err: %v, got: %v", err == workErr, workErr, err)
+
+	_, err = repos.PlayerRepo.Get(1)
+	test.Assert(t, "playerRepo.Get(), want ErrNotFound, got: %v", errors.Cause(err) == scores.ErrNotFound, err)
+}
@@ -2,7 +2,6 @@ package sql
 
 import (
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
 	"github.com/raphi011/scores-api"
@@ -13,7 +12,7 @@ import (
 var _ repo.UserRepository = &userRepository{}
 
 type userRepository struct {
-	DB *sqlx.DB
+	DB crud.DB
 }
 
 // New persists a user and assigns a new id.
@@ -0,0 +1,122 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/repo"
+	"github.com/raphi011/scores-api/repo/sql/crud"
+)
+
+var _ repo.WebhookRepository = &webhookRepository{}
+
+type webhookRepository struct {
+	DB crud.DB
+}
+
+// All loads all webhooks.
+func (s *webhookRepository) All() ([]*scores.Webhook, error) {
+	webhooks := []*scores.Webhook{}
+	err := crud.Read(s.DB, "webhook/select-all", &webhooks)
+
+	return webhooks, errors.Wrap(err, "all webhooks")
+}
+
+// Get loads a webhook.
+func (s *webhookRepository) Get(id int) (*scores.Webhook, error) {
+	webhook := &scores.Webhook{}
+	err := crud.ReadOne(s.DB, "webhook/select-by-id", webhook, id)
+
+	return webhook, errors.Wrap(err, "get webhook")
+}
+
+// New persists a webhook and assigns a new id.
+func (s *webhookRepository) New(w *scores.Webhook) (*scores.Webhook, error) {
+	err := crud.CreateSetID(s.DB, "webhook/insert", w)
+
+	return w, errors.Wrap(err, "insert webhook")
+}
+
+// Delete deletes a webhook.
+func (s *webhookRepository) Delete(w *scores.Webhook) error {
+	err := crud.Delete(s.DB, "webhook/delete", w)
+
+	return errors.Wrap(err, "delete webhook")
+}
+
+var _ repo.WebhookDeliveryRepository = &webhookDeliveryRepository{}
+
+type webhookDeliveryRepository struct {
+	DB crud.DB
+}
+
+// Get loads a webhook delivery.
+func (s *webhookDeliveryRepository) Get(id int) (*scores.WebhookDelivery, error) {
+	delivery := &scores.WebhookDelivery{}
+	err := crud.ReadOne(s.DB, "webhook-delivery/select-by-id", delivery, id)
+
+	return delivery, errors.Wrap(err, "get webhook delivery")
+}
+
+// New persists a webhook delivery and assigns a new id.
+func (s *webhookDeliveryRepository) New(d *scores.WebhookDelivery) (*scores.WebhookDelivery, error) {
+	err := crud.CreateSetID(s.DB, "webhook-delivery/insert", d)
+
+	return d, errors.Wrap(err, "insert webhook delivery")
+}
+
+// Update updates a webhook delivery.
+func (s *webhookDeliveryRepository) Update(d *scores.WebhookDelivery) error {
+	err := crud.Update(s.DB, "webhook-delivery/update", d)
+
+	return errors.Wrap(err, "update webhook delivery")
+}
+
+// Due loads up to `limit` pending deliveries whose next attempt is before `now`.
+func (s *webhookDeliveryRepository) Due(now time.Time, limit int) ([]*scores.WebhookDelivery, error) {
+	deliveries := []*scores.WebhookDelivery{}
+	err := crud.Read(s.DB, "webhook-delivery/select-due", &deliveries, now, limit)
+
+	return deliveries, errors.Wrap(err, "due webhook deliveries")
+}
+
+// Claim marks a due delivery as being sent until `until`, it returns false if
+// the delivery is not due anymore, e.g. because another instance claimed it.
+func (s *webhookDeliveryRepository) Claim(d *scores.WebhookDelivery, now, until time.Time) (bool, error) {
+	rowsAffected, err := crud.ExecuteArgs(s.DB, "webhook-delivery/claim", now, until, d.ID, now)
+
+	if err != nil || rowsAffected != 1 {
+		return false, errors.Wrap(err, "claim webhook delivery")
+	}
+
+	d.Status = scores.DeliverySending
+	d.NextAttempt = &until
+
+	return true, nil
+}
+
+// Page loads `limit` deliveries of a webhook starting at `offset`, the latest delivery first.
+func (s *webhookDeliveryRepository) Page(webhookID, offset, limit int) ([]*scores.WebhookDelivery, error) {
+	deliveries := []*scores.WebhookDelivery{}
+	err := crud.Read(s.DB, "webhook-delivery/select-page-by-webhook-id", &deliveries, webhookID, limit, offset)
+
+	return deliveries, errors.Wrap(err, "page webhook deliveries")
+}
+
+// WebhookIDs returns the ids of the webhooks that have a delivery of the event `eventID`.
+func (s *webhookDeliveryRepository) WebhookIDs(eventID uint64) ([]int, error) {
+	ids := []int{}
+	err := crud.Read(s.DB, "webhook-delivery/select-webhook-ids-by-event-id", &ids, eventID)
+
+	return ids, errors.Wrap(err, "webhook ids of event")
+}
+
+// LastEventID returns the highest event id that a delivery was created for.
+func (s *webhookDeliveryRepository) LastEventID() (uint64, error) {
+	var id uint64
+	err := crud.ReadOne(s.DB, "webhook-delivery/select-last-event-id", &id)
+
+	return id, errors.Wrap(err, "last webhook delivery event id")
+}
@@ -0,0 +1,68 @@
+// +build repository
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	db := SetupDB(t)
+	webhookRepo := &webhookRepository{DB: db}
+	deliveryRepo := &webhookDeliveryRepository{DB: db}
+
+	webhook, err := webhookRepo.New(&scores.Webhook{URL: "https://example.com", Events: "volleynet/*", Secret: "secret"})
+	test.Check(t, "webhookRepository.New(), err: %v", err)
+
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	for _, next := range []*time.Time{&now, &later} {
+		_, err = deliveryRepo.New(&scores.WebhookDelivery{
+			WebhookID:   webhook.ID,
+			EventID:     1,
+			EventName:   "volleynet/tournament/created",
+			Payload:     "{}",
+			Status:      scores.DeliveryPending,
+			NextAttempt: next,
+		})
+		test.Check(t, "webhookDeliveryRepository.New(), err: %v", err)
+	}
+
+	due, err := deliveryRepo.Due(now.Add(time.Minute), 10)
+	test.Check(t, "webhookDeliveryRepository.Due(), err: %v", err)
+	test.Assert(t, "webhookDeliveryRepository.Due(), want 1 delivery, got: %d", len(due) == 1, len(due))
+
+	claimed, err := deliveryRepo.Claim(due[0], now.Add(time.Minute), now.Add(2*time.Minute))
+	test.Check(t, "webhookDeliveryRepository.Claim(), err: %v", err)
+	test.Assert(t, "webhookDeliveryRepository.Claim(), want the delivery to be claimed", claimed)
+
+	claimed, err = deliveryRepo.Claim(due[0], now.Add(time.Minute), now.Add(2*time.Minute))
+	test.Check(t, "webhookDeliveryRepository.Claim(), err: %v", err)
+	test.Assert(t, "webhookDeliveryRepository.Claim(), want a claimed delivery not to be claimed again", !claimed)
+
+	ids, err := deliveryRepo.WebhookIDs(1)
+	test.Check(t, "webhookDeliveryRepository.WebhookIDs(), err: %v", err)
+	test.Assert(t, "webhookDeliveryRepository.WebhookIDs(), want 2 ids, got: %v", len(ids) == 2, ids)
+
+	due[0].Status = scores.DeliveryDelivered
+	due[0].NextAttempt = nil
+
+	err = deliveryRepo.Update(due[0])
+	test.Check(t, "webhookDeliveryRepository.Update(), err: %v", err)
+
+	page, err := deliveryRepo.Page(webhook.ID, 0, 10)
+	test.Check(t, "webhookDeliveryRepository.Page(), err: %v", err)
+	test.Assert(t, "webhookDeliveryRepository.Page(), want 2 deliveries, got: %d", len(page) == 2, len(page))
+
+	err = webhookRepo.Delete(webhook)
+	test.Check(t, "webhookRepository.Delete(), err: %v", err)
+
+	webhooks, err := webhookRepo.All()
+	test.Check(t, "webhookRepository.All(), err: %v", err)
+	test.Assert(t, "webhookRepository.All(), want 0 webhooks after delete, got: %d", len(webhooks) == 0, len(webhooks))
+}
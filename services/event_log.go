@@ -0,0 +1,69 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/repo"
+)
+
+var _ events.Store = &EventLog{}
+
+// EventLog persists the published events in the repository, it is
+// used as the `Store` of an `events.Broker`.
+type EventLog struct {
+	Repo repo.EventLogRepository
+}
+
+// Append persists an event and returns its sequence number.
+func (s *EventLog) Append(event events.Event) (uint64, error) {
+	body, err := json.Marshal(event.Body)
+
+	if err != nil {
+		return 0, errors.Wrap(err, "marshal event body")
+	}
+
+	entry, err := s.Repo.New(&scores.EventLogEntry{
+		Name: event.Name,
+		Body: string(body),
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(entry.ID), nil
+}
+
+// Since loads up to `limit` events with a sequence number greater than `id`.
+func (s *EventLog) Since(id uint64, limit int) ([]events.Event, error) {
+	entries, err := s.Repo.Since(int(id), limit)
+
+	return toEvents(entries), err
+}
+
+// From loads up to `limit` events that were published at or after
+// `from` and have a sequence number greater than `id`.
+func (s *EventLog) From(from time.Time, id uint64, limit int) ([]events.Event, error) {
+	entries, err := s.Repo.From(from, int(id), limit)
+
+	return toEvents(entries), err
+}
+
+func toEvents(entries []*scores.EventLogEntry) []events.Event {
+	evts := make([]events.Event, len(entries))
+
+	for i, e := range entries {
+		evts[i] = events.Event{
+			ID:   uint64(e.ID),
+			Name: e.Name,
+			Body: json.RawMessage(e.Body),
+		}
+	}
+
+	return evts
+}
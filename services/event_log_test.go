@@ -0,0 +1,33 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/repo/sql"
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestEventLogResumesAfterRestart(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+
+	broker := &events.Broker{Store: &EventLog{Repo: repos.EventLogRepo}}
+	broker.Publish(events.Event{Name: "volleynet/tournament/created", Body: map[string]int{"id": 1}})
+	broker.Publish(events.Event{Name: "volleynet/tournament/canceled", Body: map[string]int{"id": 2}})
+
+	// a new broker with the same store continues the sequence
+	restarted := &events.Broker{Store: &EventLog{Repo: repos.EventLogRepo}}
+
+	missed, _, more, err := restarted.Since("volleynet/*", 0)
+	test.Check(t, "broker.Since() err: %v", err)
+	test.Assert(t, "broker.Since() want no more events", !more)
+	test.Assert(t, "broker.Since() want 2 events, got: %d", len(missed) == 2, len(missed))
+
+	body, _ := json.Marshal(missed[1].Body)
+	test.Equal(t, "broker.Since() want body %s, got %s", `{"id":2}`, string(body))
+
+	missed, _, _, err = restarted.Since("volleynet/*", missed[0].ID)
+	test.Check(t, "broker.Since() err: %v", err)
+	test.Equal(t, "broker.Since() want event %q, got %q", "volleynet/tournament/canceled", missed[0].Name)
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"time"
+
+	"github.com/raphi011/scores-api/job"
+	"github.com/raphi011/scores-api/repo"
+)
+
+var _ job.Locker = &JobLeases{}
+
+// JobLeases persists the leases on the jobs in the repository so that a job
+// runs on a single instance at a time, it is used as the `Locker` of a
+// `job.Manager`.
+type JobLeases struct {
+	Repo repo.JobLeaseRepository
+}
+
+// Acquire acquires or renews the lease of `holder` on a job.
+func (s *JobLeases) Acquire(jobName, holder string, ttl time.Duration) (bool, error) {
+	return s.Repo.Acquire(jobName, holder, ttl)
+}
+
+// Release gives up the lease of `holder` on a job.
+func (s *JobLeases) Release(jobName, holder string) error {
+	return s.Repo.Release(jobName, holder)
+}
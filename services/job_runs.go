@@ -0,0 +1,50 @@
+package services
+
+import (
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/job"
+	"github.com/raphi011/scores-api/repo"
+)
+
+var _ job.Recorder = &JobRuns{}
+
+// JobRuns persists the runs of the jobs in the repository, it is
+// used as the `Recorder` of a `job.Manager`.
+type JobRuns struct {
+	Repo repo.JobRunRepository
+}
+
+// Record persists a finished run.
+func (s *JobRuns) Record(run *job.Run) error {
+	_, err := s.Repo.New(&scores.JobRun{
+		Job:        run.Job,
+		Start:      run.Start,
+		End:        run.End,
+		DurationMS: run.End.Sub(run.Start).Milliseconds(),
+		Outcome:    run.Outcome,
+		Error:      run.Error,
+		Log:        run.Log,
+	})
+
+	return err
+}
+
+// Runs returns `limit` runs of the job `jobName` starting at `offset` and the
+// total number of its runs, the latest run first. The runs of all jobs are
+// returned if `jobName` is empty. The logs are only loaded by `Get`.
+func (s *JobRuns) Runs(jobName string, offset, limit int) ([]*scores.JobRun, int, error) {
+	runs, err := s.Repo.Page(jobName, offset, limit)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.Repo.Count(jobName)
+
+	return runs, total, err
+}
+
+// Get returns a run with its log.
+func (s *JobRuns) Get(runID int) (*scores.JobRun, error) {
+	return s.Repo.Get(runID)
+}
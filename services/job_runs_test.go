@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/job"
+	"github.com/raphi011/scores-api/repo/sql"
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestJobRunsRecordsRuns(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+	jobRuns := &JobRuns{Repo: repos.JobRunRepo}
+
+	manager := job.NewManager()
+	manager.Recorder = jobRuns
+
+	err := manager.Start(job.Job{
+		Name:    "Tournaments",
+		MaxRuns: 1,
+		Do:      func(ctx context.Context) error { return errors.New("volleynet is down") },
+	})
+	test.Check(t, "manager.Start() err: %v", err)
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		_, total, err := jobRuns.Runs("Tournaments", 0, 10)
+		test.Check(t, "jobRuns.Runs() err: %v", err)
+
+		if total > 0 {
+			break
+		}
+
+		select {
+		case <-timeout:
+			t.Fatal("the run was not recorded")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	page, total, _ := jobRuns.Runs("", 0, 10)
+	test.Equal(t, "jobRuns.Runs() want %d runs, got %d", 1, total)
+	test.Equal(t, "jobRuns.Runs() want outcome %q, got %q", job.OutcomeFailed, page[0].Outcome)
+	test.Equal(t, "jobRuns.Runs() want error %q, got %q", "volleynet is down", page[0].Error)
+
+	run, err := jobRuns.Get(page[0].ID)
+	test.Check(t, "jobRuns.Get() err: %v", err)
+	test.Assert(t, "jobRuns.Get() want the failure in the log, got: %q", strings.Contains(run.Log, "volleynet is down"), run.Log)
+}
@@ -6,14 +6,21 @@ import (
 )
 
 type Metrics struct {
-	tournamentSignups *prometheus.CounterVec
+	tournamentSignups     *prometheus.CounterVec
+	tournamentWithdrawals *prometheus.CounterVec
 }
 
+var tournamentLabels = []string{"league_key", "sub_league_key", "gender"}
+
 var m = &Metrics{
 	tournamentSignups: promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "api_tournament_signups",
 		Help: "The total number of tournament signups",
-	}, []string{}),
+	}, tournamentLabels),
+	tournamentWithdrawals: promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "api_tournament_withdrawals",
+		Help: "The total number of tournament withdrawals",
+	}, tournamentLabels),
 }
 
 func NewMetrics() *Metrics {
@@ -3,10 +3,12 @@ package services
 import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/raphi011/scores-api"
 	"github.com/raphi011/scores-api/repo"
 	"github.com/raphi011/scores-api/volleynet"
 	volleynet_client "github.com/raphi011/scores-api/volleynet/client"
 
+	"context"
 	"strconv"
 	"time"
 )
@@ -16,8 +18,7 @@ type Volleynet struct {
 	TeamRepo       repo.TeamRepository
 	PlayerRepo     repo.PlayerRepository
 	TournamentRepo repo.TournamentRepository
-
-	VolleynetClient volleynet_client.Client
+	ChangeRepo     repo.TournamentChangeRepository
 
 	Metrics *Metrics
 }
@@ -26,12 +27,14 @@ func NewVolleynetService(
 	teamRepo repo.TeamRepository,
 	playerRepo repo.PlayerRepository,
 	tournamentRepo repo.TournamentRepository,
+	changeRepo repo.TournamentChangeRepository,
 	metrics *Metrics,
 ) *Volleynet {
 	return &Volleynet{
 		TeamRepo:       teamRepo,
 		PlayerRepo:     playerRepo,
 		TournamentRepo: tournamentRepo,
+		ChangeRepo:     changeRepo,
 
 		Metrics: metrics,
 	}
@@ -165,7 +168,21 @@ func (s *Volleynet) TournamentInfo(tournamentID int) (
 	return nil, err
 }
 
-func (s *Volleynet) EnterTournament(partnerID, tournamentID int) error {
+// TournamentHistory loads all changes of a tournament
+// and its teams, the latest change first.
+func (s *Volleynet) TournamentHistory(tournamentID int) (
+	[]*volleynet.TournamentChange, error) {
+	_, err := s.TournamentRepo.Get(tournamentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ChangeRepo.ByTournament(tournamentID)
+}
+
+// EnterTournament signs up the logged in user of `session` with the partner for a tournament.
+func (s *Volleynet) EnterTournament(ctx context.Context, session volleynet_client.Client, partnerID, tournamentID int) error {
 	partner, err := s.PlayerRepo.Get(partnerID)
 
 	if err != nil {
@@ -178,7 +195,7 @@ func (s *Volleynet) EnterTournament(partnerID, tournamentID int) error {
 		return errors.Wrap(err, "signup: error while retrieving tournament")
 	}
 
-	err = s.VolleynetClient.EnterTournament(partner.ID, tournament.ID)
+	err = session.EnterTournament(ctx, partner.ID, tournament.ID)
 
 	if err != nil {
 		return errors.Wrapf(err, "entering tournament %d with partner %d failed", partnerID, tournamentID)
@@ -189,10 +206,66 @@ func (s *Volleynet) EnterTournament(partnerID, tournamentID int) error {
 	return nil
 }
 
-func (s *Volleynet) incTournamentSignupMetric(t *volleynet.Tournament) {
-	s.Metrics.tournamentSignups.With(prometheus.Labels{
+// WithdrawFromTournament withdraws the logged in player of `session` from a tournament
+// and marks the team as deregistered.
+func (s *Volleynet) WithdrawFromTournament(ctx context.Context, session volleynet_client.Client, playerID, tournamentID int) error {
+	tournament, err := s.TournamentRepo.Get(tournamentID)
+
+	if err != nil {
+		return errors.Wrap(err, "withdrawal: error while retrieving tournament")
+	}
+
+	teams, err := s.TeamRepo.ByTournament(tournamentID)
+
+	if err != nil {
+		return errors.Wrap(err, "withdrawal: error while retrieving teams")
+	}
+
+	team := findTeamOfPlayer(teams, playerID)
+
+	if team == nil {
+		return errors.Wrapf(scores.ErrNotFound, "player %d is not signed up for tournament %d", playerID, tournamentID)
+	}
+
+	err = session.WithdrawFromTournament(ctx, tournamentID)
+
+	if err != nil {
+		return errors.Wrapf(err, "withdrawing player %d from tournament %d failed", playerID, tournamentID)
+	}
+
+	s.incTournamentWithdrawalMetric(tournament)
+
+	// the next sync would notice the withdrawal as well,
+	// but users expect to see it straight away
+	team.Deregistered = true
+
+	err = s.TeamRepo.Update(team)
+
+	return errors.Wrap(err, "withdrawal: error while updating team")
+}
+
+func findTeamOfPlayer(teams []*volleynet.TournamentTeam, playerID int) *volleynet.TournamentTeam {
+	for _, t := range teams {
+		if t.Player1.ID == playerID || t.Player2.ID == playerID {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func tournamentMetricLabels(t *volleynet.Tournament) prometheus.Labels {
+	return prometheus.Labels{
 		"league_key":     t.LeagueKey,
 		"sub_league_key": t.SubLeagueKey,
 		"gender":         t.Gender,
-	}).Inc()
+	}
+}
+
+func (s *Volleynet) incTournamentSignupMetric(t *volleynet.Tournament) {
+	s.Metrics.tournamentSignups.With(tournamentMetricLabels(t)).Inc()
+}
+
+func (s *Volleynet) incTournamentWithdrawalMetric(t *volleynet.Tournament) {
+	s.Metrics.tournamentWithdrawals.With(tournamentMetricLabels(t)).Inc()
 }
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	volleynet_client "github.com/raphi011/scores-api/volleynet/client"
+)
+
+type volleynetSession struct {
+	client  volleynet_client.Client
+	expires time.Time
+}
+
+// VolleynetSessions keeps the logged in volleynet clients of users for a
+// short time, this allows users to signup or withdraw from tournaments
+// without entering their password again.
+type VolleynetSessions struct {
+	TTL time.Duration
+
+	mutex    sync.Mutex
+	sessions map[uuid.UUID]*volleynetSession
+	now      func() time.Time
+}
+
+// NewVolleynetSessions creates a new session store, sessions expire after `ttl`.
+func NewVolleynetSessions(ttl time.Duration) *VolleynetSessions {
+	return &VolleynetSessions{
+		TTL:      ttl,
+		sessions: make(map[uuid.UUID]*volleynetSession),
+		now:      time.Now,
+	}
+}
+
+// Set stores the logged in `client` of a user and removes all expired sessions.
+func (s *VolleynetSessions) Set(userID uuid.UUID, client volleynet_client.Client) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+
+	for id, session := range s.sessions {
+		if !now.Before(session.expires) {
+			delete(s.sessions, id)
+		}
+	}
+
+	s.sessions[userID] = &volleynetSession{
+		client:  client,
+		expires: now.Add(s.TTL),
+	}
+}
+
+// Get returns the logged in client of a user if the session has not expired yet.
+func (s *VolleynetSessions) Get(userID uuid.UUID) (volleynet_client.Client, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, ok := s.sessions[userID]
+
+	if !ok {
+		return nil, false
+	}
+
+	if !s.now().Before(session.expires) {
+		delete(s.sessions, userID)
+		return nil, false
+	}
+
+	return session.client, true
+}
+
+// Delete removes the session of a user.
+func (s *VolleynetSessions) Delete(userID uuid.UUID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.sessions, userID)
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/raphi011/scores-api/volleynet/mocks"
+)
+
+func TestVolleynetSessionsExpire(t *testing.T) {
+	now := time.Now()
+
+	sessions := NewVolleynetSessions(10 * time.Minute)
+	sessions.now = func() time.Time { return now }
+
+	userID := uuid.New()
+	sessions.Set(userID, &mocks.ClientMock{})
+
+	if _, ok := sessions.Get(userID); !ok {
+		t.Error("VolleynetSessions.Get(), want session, got none")
+	}
+
+	now = now.Add(10 * time.Minute)
+
+	if _, ok := sessions.Get(userID); ok {
+		t.Error("VolleynetSessions.Get(), want expired session to be removed")
+	}
+}
+
+func TestVolleynetSessionsDelete(t *testing.T) {
+	sessions := NewVolleynetSessions(10 * time.Minute)
+
+	userID := uuid.New()
+	sessions.Set(userID, &mocks.ClientMock{})
+	sessions.Delete(userID)
+
+	if _, ok := sessions.Get(userID); ok {
+		t.Error("VolleynetSessions.Get(), want deleted session to be removed")
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raphi011/scores-api/repo/sql"
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet/mocks"
+)
+
+func TestWithdrawFromTournament(t *testing.T) {
+	repos, db := sql.RepositoriesTest(t)
+
+	service := NewVolleynetService(repos.TeamRepo, repos.PlayerRepo, repos.TournamentRepo, repos.ChangeRepo, NewMetrics())
+
+	players := sql.CreatePlayers(t, db, sql.P{ID: 1}, sql.P{ID: 2})
+	sql.CreateTournaments(t, db, sql.T{ID: 1})
+	sql.CreateTeams(t, db, sql.TT{TournamentID: 1, Player1: players[0], Player2: players[1]})
+
+	session := new(mocks.ClientMock)
+	session.On("WithdrawFromTournament", 1).Return(nil)
+
+	err := service.WithdrawFromTournament(context.Background(), session, 2, 1)
+	test.Check(t, "service.WithdrawFromTournament() err: %v", err)
+
+	session.AssertExpectations(t)
+
+	teams, err := repos.TeamRepo.ByTournament(1)
+	test.Check(t, "teamRepo.ByTournament() err: %v", err)
+	test.Assert(t, "service.WithdrawFromTournament() want: .Deregistered = true", teams[0].Deregistered)
+}
+
+func TestWithdrawFromTournamentNotSignedUp(t *testing.T) {
+	repos, db := sql.RepositoriesTest(t)
+
+	service := NewVolleynetService(repos.TeamRepo, repos.PlayerRepo, repos.TournamentRepo, repos.ChangeRepo, NewMetrics())
+
+	sql.CreateTournaments(t, db, sql.T{ID: 1})
+
+	session := new(mocks.ClientMock)
+
+	err := service.WithdrawFromTournament(context.Background(), session, 2, 1)
+	test.Assert(t, "service.WithdrawFromTournament() want err, got nil", err != nil)
+
+	session.AssertNotCalled(t, "WithdrawFromTournament", 1)
+}
@@ -0,0 +1,499 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/repo"
+)
+
+// headers of a webhook request.
+const (
+	WebhookSignatureHeader = "X-Scores-Signature"
+	WebhookEventHeader     = "X-Scores-Event"
+	WebhookDeliveryHeader  = "X-Scores-Delivery"
+)
+
+// Webhooks delivers published events to the registered webhooks. Failed
+// deliveries are retried with an exponential backoff until `MaxAttempts`
+// is reached, after that they are dead-lettered.
+type Webhooks struct {
+	Repo         repo.WebhookRepository
+	DeliveryRepo repo.WebhookDeliveryRepository
+
+	Client       *http.Client
+	MaxAttempts  int
+	RetryDelay   time.Duration // the delay after the first failed attempt, it doubles with every attempt
+	MaxDelay     time.Duration
+	ClaimTimeout time.Duration // how long an instance may take to send a delivery before it is sent again
+}
+
+// NewWebhooksService creates a webhook service with the default retry policy.
+func NewWebhooksService(
+	webhookRepo repo.WebhookRepository,
+	deliveryRepo repo.WebhookDeliveryRepository,
+) *Webhooks {
+	return &Webhooks{
+		Repo:         webhookRepo,
+		DeliveryRepo: deliveryRepo,
+
+		Client:       &http.Client{Timeout: 10 * time.Second},
+		MaxAttempts:  8,
+		RetryDelay:   30 * time.Second,
+		MaxDelay:     time.Hour,
+		ClaimTimeout: time.Minute,
+	}
+}
+
+// All returns all registered webhooks.
+func (s *Webhooks) All() ([]*scores.Webhook, error) {
+	return s.Repo.All()
+}
+
+// Create registers a webhook that receives the events matching the comma
+// separated `eventNames`, a random secret is generated if `secret` is empty.
+func (s *Webhooks) Create(webhookURL, eventNames, secret string) (*scores.Webhook, error) {
+	u, err := url.Parse(webhookURL)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Wrapf(scores.ErrorValidation, "invalid webhook url %q", webhookURL)
+	}
+
+	names := splitEventNames(eventNames)
+
+	if len(names) == 0 {
+		return nil, errors.Wrap(scores.ErrorValidation, "a webhook needs at least one event")
+	}
+
+	if secret == "" {
+		if secret, err = randomSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Repo.New(&scores.Webhook{
+		URL:    webhookURL,
+		Events: strings.Join(names, ","),
+		Secret: secret,
+	})
+}
+
+// Delete removes a webhook, its pending deliveries are not sent anymore.
+func (s *Webhooks) Delete(webhookID int) error {
+	webhook, err := s.Repo.Get(webhookID)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Repo.Delete(webhook)
+}
+
+// Deliveries returns `limit` deliveries of a webhook starting at `offset`.
+func (s *Webhooks) Deliveries(webhookID, offset, limit int) ([]*scores.WebhookDelivery, error) {
+	if _, err := s.Repo.Get(webhookID); err != nil {
+		return nil, err
+	}
+
+	return s.DeliveryRepo.Page(webhookID, offset, limit)
+}
+
+// Retry queues a dead-lettered delivery to be sent again.
+func (s *Webhooks) Retry(deliveryID int) (*scores.WebhookDelivery, error) {
+	delivery, err := s.DeliveryRepo.Get(deliveryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Status != scores.DeliveryDead {
+		return nil, errors.Wrapf(scores.ErrorValidation, "delivery %d is %s", deliveryID, delivery.Status)
+	}
+
+	now := time.Now()
+
+	delivery.Status = scores.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttempt = &now
+
+	return delivery, s.DeliveryRepo.Update(delivery)
+}
+
+// Run queues a delivery for every webhook that matches an event named
+// `eventName` and sends the due deliveries every `interval` in another
+// goroutine, so a slow webhook never holds up the subscription. The
+// subscription drops the events it can't buffer instead of blocking the
+// publisher, the missed events are queued from the broker's store or history
+// once the gap is noticed. If the broker has a store the events that were
+// published since the last queued delivery are queued first. It returns
+// when `ctx` is done or the broker is closed.
+func (s *Webhooks) Run(ctx context.Context, broker *events.Broker, eventName string, interval time.Duration) {
+	evts, unsubscribe := broker.Subscribe(eventName,
+		events.WithBufferSize(256),
+		events.WithOverflowPolicy(events.DropNewest),
+	)
+
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(ctx)
+	wg := sync.WaitGroup{}
+
+	defer wg.Wait()
+	defer cancel()
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		s.deliverEvery(ctx, interval)
+	}()
+
+	var lastID uint64
+
+	if broker.Store != nil {
+		var err error
+
+		if lastID, err = s.DeliveryRepo.LastEventID(); err != nil {
+			zap.S().Errorf("could not resume webhook deliveries: %+v", err)
+			return
+		}
+
+		// if nothing was queued yet the whole event log isn't sent
+		if lastID > 0 {
+			lastID = s.enqueueMissed(broker, eventName, lastID)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-evts:
+			if !ok {
+				return
+			}
+
+			switch {
+			case event.Replay || event.ID == 0:
+				// replayed events and events that could not be stored are
+				// not part of the sequence
+				s.enqueue(event)
+			case event.ID <= lastID:
+				// queued when the gap was filled
+			case lastID == 0 || event.ID == lastID+1:
+				s.enqueue(event)
+				lastID = event.ID
+			default:
+				lastID = s.enqueueMissed(broker, eventName, lastID)
+			}
+		}
+	}
+}
+
+// deliverEvery sends the due deliveries every `interval` until `ctx` is done.
+func (s *Webhooks) deliverEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.DeliverDue(ctx); err != nil {
+				zap.S().Warnf("could not deliver webhooks: %+v", err)
+			}
+		}
+	}
+}
+
+func (s *Webhooks) enqueue(event events.Event) {
+	if err := s.Enqueue(event); err != nil {
+		zap.S().Warnf("could not queue webhook deliveries of event %s: %+v", event.Name, err)
+	}
+}
+
+// enqueueMissed queues the events after the event `id` that are in the
+// broker's store or history and returns the id of the last queued event.
+// Events that already have deliveries, e.g. because the instance that
+// published them queued them, are skipped.
+func (s *Webhooks) enqueueMissed(broker *events.Broker, eventName string, id uint64) uint64 {
+	for {
+		missed, next, more, err := broker.Since(eventName, id)
+
+		if err != nil {
+			zap.S().Warnf("could not load missed webhook events: %+v", err)
+			return id
+		}
+
+		for _, event := range missed {
+			if err = s.enqueueOnce(event); err != nil {
+				zap.S().Warnf("could not queue webhook deliveries of event %s: %+v", event.Name, err)
+				return id
+			}
+
+			id = event.ID
+		}
+
+		if next > id {
+			id = next
+		}
+
+		if !more {
+			return id
+		}
+	}
+}
+
+// Enqueue creates a pending delivery of `event` for every matching webhook.
+func (s *Webhooks) Enqueue(event events.Event) error {
+	return s.enqueueFor(event, nil)
+}
+
+// enqueueOnce is like Enqueue but skips the webhooks that
+// already have a delivery of `event`.
+func (s *Webhooks) enqueueOnce(event events.Event) error {
+	queued, err := s.DeliveryRepo.WebhookIDs(event.ID)
+
+	if err != nil {
+		return err
+	}
+
+	skip := map[int]bool{}
+
+	for _, id := range queued {
+		skip[id] = true
+	}
+
+	return s.enqueueFor(event, skip)
+}
+
+func (s *Webhooks) enqueueFor(event events.Event, skip map[int]bool) error {
+	webhooks, err := s.Repo.All()
+
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	now := time.Now()
+
+	for _, w := range webhooks {
+		if skip[w.ID] || !webhookMatches(w, event.Name) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return errors.Wrap(err, "marshal event")
+			}
+		}
+
+		_, err = s.DeliveryRepo.New(&scores.WebhookDelivery{
+			WebhookID:   w.ID,
+			EventID:     event.ID,
+			EventName:   event.Name,
+			Payload:     string(payload),
+			Status:      scores.DeliveryPending,
+			NextAttempt: &now,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeliverDue sends the pending deliveries whose next attempt is due. Every
+// delivery is claimed before it is sent, so with multiple instances each
+// delivery is only sent by one of them.
+func (s *Webhooks) DeliverDue(ctx context.Context) error {
+	deliveries, err := s.DeliveryRepo.Due(time.Now(), 100)
+
+	if err != nil {
+		return err
+	}
+
+	webhooks := map[int]*scores.Webhook{}
+
+	for _, d := range deliveries {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		now := time.Now()
+		claimed, err := s.DeliveryRepo.Claim(d, now, now.Add(s.ClaimTimeout))
+
+		if err != nil {
+			return err
+		} else if !claimed {
+			continue
+		}
+
+		webhook, ok := webhooks[d.WebhookID]
+
+		if !ok {
+			webhook, err = s.Repo.Get(d.WebhookID)
+
+			if errors.Cause(err) == scores.ErrNotFound {
+				// the webhook was deleted
+				webhook = nil
+			} else if err != nil {
+				return err
+			}
+
+			webhooks[d.WebhookID] = webhook
+		}
+
+		if webhook == nil {
+			s.fail(d, 0, "the webhook was deleted", true)
+		} else {
+			s.deliver(ctx, webhook, d)
+		}
+
+		if err = s.DeliveryRepo.Update(d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Webhooks) deliver(ctx context.Context, webhook *scores.Webhook, d *scores.WebhookDelivery) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(d.Payload))
+
+	if err != nil {
+		s.fail(d, 0, err.Error(), true)
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, d.EventName)
+	req.Header.Set(WebhookDeliveryHeader, strconv.Itoa(d.ID))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, []byte(d.Payload)))
+
+	resp, err := s.Client.Do(req)
+
+	if err != nil {
+		s.fail(d, 0, err.Error(), false)
+		return
+	}
+
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message := fmt.Sprintf("unexpected status %d", resp.StatusCode)
+
+		if b := strings.TrimSpace(string(bytes.ToValidUTF8(body, nil))); b != "" {
+			message += ": " + b
+		}
+
+		s.fail(d, resp.StatusCode, message, false)
+		return
+	}
+
+	now := time.Now()
+
+	d.Attempts++
+	d.Status = scores.DeliveryDelivered
+	d.ResponseStatus = resp.StatusCode
+	d.LastError = ""
+	d.NextAttempt = nil
+	d.DeliveredAt = &now
+}
+
+// fail records a failed attempt and schedules the next one, the delivery is
+// dead-lettered if `permanent` is set or there are no attempts left.
+func (s *Webhooks) fail(d *scores.WebhookDelivery, status int, message string, permanent bool) {
+	d.Attempts++
+	d.ResponseStatus = status
+	d.LastError = message
+
+	if permanent || d.Attempts >= s.MaxAttempts {
+		d.Status = scores.DeliveryDead
+		d.NextAttempt = nil
+		return
+	}
+
+	next := time.Now().Add(s.retryDelay(d.Attempts))
+	d.NextAttempt = &next
+}
+
+// retryDelay returns the delay after the `attempt`th failed attempt.
+func (s *Webhooks) retryDelay(attempt int) time.Duration {
+	delay := s.RetryDelay
+
+	for i := 1; i < attempt && delay < s.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > s.MaxDelay {
+		delay = s.MaxDelay
+	}
+
+	return delay
+}
+
+// SignWebhookPayload returns the value of the signature header, which is
+// the hex encoded HMAC-SHA256 of the payload prefixed with "sha256=".
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookMatches(w *scores.Webhook, eventName string) bool {
+	for _, name := range splitEventNames(w.Events) {
+		if events.Matches(name, eventName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitEventNames(eventNames string) []string {
+	names := []string{}
+
+	for _, name := range strings.Split(eventNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func randomSecret() (string, error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generate webhook secret")
+	}
+
+	return fmt.Sprintf("%x", b), nil
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/repo/sql"
+	"github.com/raphi011/scores-api/test"
+)
+
+func TestWebhookDelivery(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+	service := NewWebhooksService(repos.WebhookRepo, repos.DeliveryRepo)
+
+	received := make(chan *http.Request, 1)
+	payloads := make(chan []byte, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- r
+		payloads <- body
+	}))
+	defer server.Close()
+
+	webhook, err := service.Create(server.URL, "volleynet/tournament/*", "secret")
+	test.Check(t, "service.Create() err: %v", err)
+
+	err = service.Enqueue(events.Event{ID: 1, Name: "volleynet/ladder/rank-changed"})
+	test.Check(t, "service.Enqueue() err: %v", err)
+
+	err = service.Enqueue(events.Event{ID: 2, Name: "volleynet/tournament/created", Body: map[string]int{"id": 1}})
+	test.Check(t, "service.Enqueue() err: %v", err)
+
+	err = service.DeliverDue(context.Background())
+	test.Check(t, "service.DeliverDue() err: %v", err)
+
+	r, payload := <-received, <-payloads
+
+	test.Equal(t, "service.DeliverDue() want event header %q, got %q", "volleynet/tournament/created", r.Header.Get(WebhookEventHeader))
+	test.Equal(t, "service.DeliverDue() want signature %q, got %q", SignWebhookPayload("secret", payload), r.Header.Get(WebhookSignatureHeader))
+
+	deliveries, err := service.Deliveries(webhook.ID, 0, 10)
+	test.Check(t, "service.Deliveries() err: %v", err)
+	test.Assert(t, "service.Deliveries() want 1 delivery, got: %d", len(deliveries) == 1, len(deliveries))
+	test.Equal(t, "service.DeliverDue() want status %q, got %q", scores.DeliveryDelivered, deliveries[0].Status)
+}
+
+func TestWebhookDeliveryRetriesAndDeadLetters(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+	service := NewWebhooksService(repos.WebhookRepo, repos.DeliveryRepo)
+	service.MaxAttempts = 2
+	service.RetryDelay = 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook, err := service.Create(server.URL, "volleynet/*", "")
+	test.Check(t, "service.Create() err: %v", err)
+	test.Assert(t, "service.Create() want a generated secret", webhook.Secret != "")
+
+	err = service.Enqueue(events.Event{ID: 1, Name: "volleynet/tournament/created"})
+	test.Check(t, "service.Enqueue() err: %v", err)
+
+	for i := 0; i < 3; i++ {
+		err = service.DeliverDue(context.Background())
+		test.Check(t, "service.DeliverDue() err: %v", err)
+	}
+
+	deliveries, err := service.Deliveries(webhook.ID, 0, 10)
+	test.Check(t, "service.Deliveries() err: %v", err)
+
+	d := deliveries[0]
+	test.Equal(t, "service.DeliverDue() want status %q, got %q", scores.DeliveryDead, d.Status)
+	test.Equal(t, "service.DeliverDue() want %d attempts, got %d", 2, d.Attempts)
+	test.Equal(t, "service.DeliverDue() want response status %d, got %d", http.StatusServiceUnavailable, d.ResponseStatus)
+
+	d, err = service.Retry(d.ID)
+	test.Check(t, "service.Retry() err: %v", err)
+	test.Equal(t, "service.Retry() want status %q, got %q", scores.DeliveryPending, d.Status)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	service := &Webhooks{RetryDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		test.Equal(t, "retryDelay() want %s, got %s", want, service.retryDelay(attempt))
+	}
+}
+
+func TestCreateWebhookValidates(t *testing.T) {
+	service := &Webhooks{}
+
+	_, err := service.Create("ftp://example.com", "volleynet/*", "")
+	test.Assert(t, "service.Create() want validation error, got: %v", err != nil, err)
+
+	_, err = service.Create("https://example.com", " , ", "")
+	test.Assert(t, "service.Create() want validation error, got: %v", err != nil, err)
+}
+
+func TestWebhookDeliveryIsClaimed(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+	service := NewWebhooksService(repos.WebhookRepo, repos.DeliveryRepo)
+
+	sent := make(chan struct{}, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent <- struct{}{}
+	}))
+	defer server.Close()
+
+	_, err := service.Create(server.URL, "volleynet/*", "")
+	test.Check(t, "service.Create() err: %v", err)
+
+	err = service.Enqueue(events.Event{ID: 1, Name: "volleynet/tournament/created"})
+	test.Check(t, "service.Enqueue() err: %v", err)
+
+	due, err := repos.DeliveryRepo.Due(time.Now(), 10)
+	test.Check(t, "DeliveryRepo.Due() err: %v", err)
+
+	// another instance is sending the delivery
+	now := time.Now()
+	claimed, err := repos.DeliveryRepo.Claim(due[0], now, now.Add(time.Minute))
+	test.Check(t, "DeliveryRepo.Claim() err: %v", err)
+	test.Assert(t, "DeliveryRepo.Claim() want the delivery to be claimed", claimed)
+
+	err = service.DeliverDue(context.Background())
+	test.Check(t, "service.DeliverDue() err: %v", err)
+
+	select {
+	case <-sent:
+		t.Fatal("service.DeliverDue() sent a claimed delivery")
+	default:
+	}
+}
+
+func TestWebhookRunDoesNotBlockPublisher(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+	service := NewWebhooksService(repos.WebhookRepo, repos.DeliveryRepo)
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	webhook, err := service.Create(server.URL, "volleynet/*", "")
+	test.Check(t, "service.Create() err: %v", err)
+
+	broker := &events.Broker{Store: &EventLog{Repo: repos.EventLogRepo}}
+
+	// queue the first event, so the events that are published
+	// before Run has subscribed are queued from the event log
+	broker.Publish(events.Event{Name: "volleynet/tournament/created"})
+
+	first, _, _, err := broker.Since("volleynet/*", 0)
+	test.Check(t, "broker.Since() err: %v", err)
+
+	err = service.Enqueue(first[0])
+	test.Check(t, "service.Enqueue() err: %v", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		service.Run(ctx, broker, "volleynet/*", time.Millisecond)
+		close(done)
+	}()
+
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	start := time.Now()
+
+	for i := 0; i < 4; i++ {
+		broker.Publish(events.Event{Name: "volleynet/tournament/created"})
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	test.Assert(t, "Publish() want not to wait for the webhook, took: %s", time.Since(start) < time.Second, time.Since(start))
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		deliveries, err := service.Deliveries(webhook.ID, 0, 10)
+		test.Check(t, "service.Deliveries() err: %v", err)
+
+		if len(deliveries) == 5 {
+			return
+		} else if time.Now().After(deadline) {
+			t.Fatalf("service.Run() want 5 queued deliveries, got: %d", len(deliveries))
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWebhookEnqueueMissed(t *testing.T) {
+	repos, _ := sql.RepositoriesTest(t)
+	service := NewWebhooksService(repos.WebhookRepo, repos.DeliveryRepo)
+
+	webhook, err := service.Create("https://example.com/hook", "volleynet/*", "")
+	test.Check(t, "service.Create() err: %v", err)
+
+	broker := &events.Broker{Store: &EventLog{Repo: repos.EventLogRepo}}
+
+	broker.Publish(events.Event{Name: "volleynet/tournament/created"})
+	broker.Publish(events.Event{Name: "volleynet/tournament/canceled"})
+
+	// the first event was already queued, e.g. by the instance that published it
+	missed, _, _, err := broker.Since("volleynet/*", 0)
+	test.Check(t, "broker.Since() err: %v", err)
+
+	err = service.Enqueue(missed[0])
+	test.Check(t, "service.Enqueue() err: %v", err)
+
+	lastID := service.enqueueMissed(broker, "volleynet/*", 0)
+	test.Equal(t, "enqueueMissed() want last id %d, got %d", missed[1].ID, lastID)
+
+	deliveries, err := service.Deliveries(webhook.ID, 0, 10)
+	test.Check(t, "service.Deliveries() err: %v", err)
+	test.Assert(t, "enqueueMissed() want 2 deliveries, got: %d", len(deliveries) == 2, len(deliveries))
+}
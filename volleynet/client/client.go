@@ -2,13 +2,14 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"os"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -20,36 +21,134 @@ import (
 // Client is the interface to the volleynet api, use DefaultClient()
 // to get a new Client.
 type Client interface {
-	Login(username, password string) (*scrape.LoginData, error)
+	Login(ctx context.Context, username, password string) (Client, *scrape.LoginData, error)
 
-	Tournaments(gender, league string, year int) ([]*volleynet.TournamentInfo, error)
-	Ladder(gender string) ([]*volleynet.Player, error)
-	ComplementTournament(tournament *volleynet.TournamentInfo) (*volleynet.Tournament, error)
+	Tournaments(ctx context.Context, gender, league string, year int) ([]*volleynet.TournamentInfo, error)
+	Ladder(ctx context.Context, gender string) ([]*volleynet.Player, error)
+	PlayerProfile(ctx context.Context, playerID int) (*scrape.ProfileData, error)
+	ComplementTournament(ctx context.Context, tournament *volleynet.TournamentInfo) (*volleynet.Tournament, error)
+	Matches(ctx context.Context, tournament *volleynet.TournamentInfo) ([]*volleynet.Match, error)
 
-	WithdrawFromTournament(tournamentID int) error
-	EnterTournament( /*playerName string, */ playerID, tournamentID int) error
+	WithdrawFromTournament(ctx context.Context, tournamentID int) error
+	EnterTournament(ctx context.Context, playerID, tournamentID int) error
 
-	SearchPlayers(firstName, lastName, birthday string) ([]*scrape.PlayerInfo, error)
+	SearchPlayers(ctx context.Context, firstName, lastName, birthday string) ([]*scrape.PlayerInfo, error)
 }
 
 // defaultClient implements the Client interface
 type defaultClient struct {
 	PostURL string
 	GetURL  string
-	Cookie  string
+
+	authenticated bool
+
+	HTTPClient      *http.Client
+	Retries         int           // # of retries of failed GET requests
+	RetryBackoff    time.Duration // backoff before the first retry, doubles with every retry
+	MaxRetryBackoff time.Duration
+
+	Limiter     *RateLimiter
+	Diagnostics *scrape.Diagnostics
 }
 
-// Default returns a Client with the correct PostURL and GetURL fields set.
-func Default() Client {
-	return &defaultClient{
+// Option is used to configure a new Client.
+type Option func(*defaultClient)
+
+// WithURLs sets the hosts the client connects to, e.g.
+// to use a fake volleynet server.
+func WithURLs(getURL, postURL string) Option {
+	return func(c *defaultClient) {
+		c.GetURL = getURL
+		c.PostURL = postURL
+	}
+}
+
+// WithHTTPClient sends all requests with `httpClient`,
+// this is useful to configure timeouts and transports.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *defaultClient) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport sends all requests with `transport`, e.g. a Recorder.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *defaultClient) {
+		httpClient := *c.HTTPClient
+		httpClient.Transport = transport
+
+		c.HTTPClient = &httpClient
+	}
+}
+
+// WithRateLimiter waits for `limiter` before sending a request, share
+// the limiter between clients to limit the total request rate.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *defaultClient) {
+		c.Limiter = limiter
+	}
+}
+
+// WithRetries retries failed GET requests up to `retries` times, waiting
+// `backoff` before the first retry and doubling it with every further one.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *defaultClient) {
+		c.Retries = retries
+		c.RetryBackoff = backoff
+	}
+}
+
+// WithDiagnostics records all parse issues to `diagnostics`.
+func WithDiagnostics(diagnostics *scrape.Diagnostics) Option {
+	return func(c *defaultClient) {
+		c.Diagnostics = diagnostics
+	}
+}
+
+// New returns a Client with the correct PostURL and GetURL fields set
+// and configures it with `opts`.
+func New(opts ...Option) Client {
+	c := &defaultClient{
 		PostURL: "https://beach.volleynet.at",
 		GetURL:  "http://www.volleynet.at",
+
+		HTTPClient:      &http.Client{Timeout: defaultTimeout},
+		Retries:         defaultRetries,
+		RetryBackoff:    defaultRetryBackoff,
+		MaxRetryBackoff: defaultMaxRetryBackoff,
 	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
 }
 
-// Login authenticates the user against the volleynet page, if
-// successfull the Client cookie is set, else an error is returned.
-func (c *defaultClient) Login(username, password string) (*scrape.LoginData, error) {
+// Default returns a Client with the correct PostURL and GetURL fields set.
+func Default() Client {
+	return New()
+}
+
+// newSession returns a copy of the client with its own cookie jar.
+func (c *defaultClient) newSession() *defaultClient {
+	// cookiejar.New never returns an error
+	jar, _ := cookiejar.New(nil)
+
+	httpClient := *c.HTTPClient
+	httpClient.Jar = jar
+
+	session := *c
+	session.HTTPClient = &httpClient
+	session.authenticated = false
+
+	return &session
+}
+
+// Login authenticates the user against the volleynet page, if successfull
+// a new Client is returned that runs in the user's session, else an
+// error is returned.
+func (c *defaultClient) Login(ctx context.Context, username, password string) (Client, *scrape.LoginData, error) {
 	form := url.Values{}
 	form.Add("login_name", username)
 	form.Add("login_pass", password)
@@ -57,39 +156,36 @@ func (c *defaultClient) Login(username, password string) (*scrape.LoginData, err
 	form.Add("submit", "OK")
 	form.Add("mode", "X")
 
+	session := c.newSession()
+
 	url := c.buildPostURL("/Admin/formular").String()
-	resp, err := http.PostForm(url, form)
+	resp, err := session.postForm(ctx, url, form)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "client login")
+		return nil, nil, errors.Wrap(err, "client login")
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("login status: %d", resp.StatusCode)
+		return nil, nil, fmt.Errorf("login status: %d", resp.StatusCode)
 	}
 
-	defer resp.Body.Close()
-
 	loginData, err := scrape.Login(resp.Body)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "parse login")
+		return nil, nil, errors.Wrap(err, "parse login")
 	}
 
-	c.Cookie = resp.Header.Get("Set-Cookie")
-
-	semicolonIndex := strings.Index(c.Cookie, ";")
-
-	if semicolonIndex > 0 {
-		c.Cookie = c.Cookie[:semicolonIndex]
-	}
+	// the session cookie is kept in the cookie jar
+	session.authenticated = true
 
-	return loginData, nil
+	return session, loginData, nil
 }
 
 // Tournaments reads all tournaments of a certain gender, league and year.
 // To get all details of a tournamnent use `Client.ComplementTournament`.
-func (c *defaultClient) Tournaments(gender, league string, year int) ([]*volleynet.TournamentInfo, error) {
+func (c *defaultClient) Tournaments(ctx context.Context, gender, league string, year int) ([]*volleynet.TournamentInfo, error) {
 	url := c.buildGetAPIURL(
 		"/beach/bewerbe/%s/phase/%s/sex/%s/saison/%d/information/all",
 		league,
@@ -98,7 +194,7 @@ func (c *defaultClient) Tournaments(gender, league string, year int) ([]*volleyn
 		year,
 	)
 
-	resp, err := http.Get(url.String())
+	resp, err := c.get(ctx, url.String())
 
 	if err != nil {
 		return nil, err
@@ -106,17 +202,17 @@ func (c *defaultClient) Tournaments(gender, league string, year int) ([]*volleyn
 
 	defer resp.Body.Close()
 
-	return scrape.TournamentList(resp.Body, c.GetURL)
+	return scrape.TournamentList(resp.Body, c.GetURL, c.Diagnostics)
 }
 
 // Ladder loads all ranked players of a certain gender.
-func (c *defaultClient) Ladder(gender string) ([]*volleynet.Player, error) {
+func (c *defaultClient) Ladder(ctx context.Context, gender string) ([]*volleynet.Player, error) {
 	url := c.buildGetAPIURL(
 		"/beach/bewerbe/Rangliste/phase/%s",
 		genderLong(gender),
 	).String()
 
-	resp, err := http.Get(url)
+	resp, err := c.get(ctx, url)
 
 	if err != nil {
 		return nil, errors.Wrapf(err, "loading ladder %q failed", gender)
@@ -124,7 +220,24 @@ func (c *defaultClient) Ladder(gender string) ([]*volleynet.Player, error) {
 
 	defer resp.Body.Close()
 
-	return scrape.Ladder(resp.Body)
+	return scrape.Ladder(resp.Body, c.Diagnostics)
+}
+
+// PlayerProfile loads the profile (steckbrief) page of a player.
+func (c *defaultClient) PlayerProfile(ctx context.Context, playerID int) (*scrape.ProfileData, error) {
+	url := c.buildGetAPIURL("/beach/information/steckbrief-%d", playerID).String()
+
+	resp, err := c.get(ctx, url)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading profile of player %d failed", playerID)
+	}
+
+	defer resp.Body.Close()
+
+	profile, err := scrape.PlayerProfile(resp.Body, playerID)
+
+	return profile, errors.Wrapf(err, "parsing profile of player %d failed", playerID)
 }
 
 func genderLong(gender string) string {
@@ -138,17 +251,26 @@ func genderLong(gender string) string {
 }
 
 // ComplementTournament adds the missing information from `Tournaments`.
-func (c *defaultClient) ComplementTournament(tournament *volleynet.TournamentInfo) (
+func (c *defaultClient) ComplementTournament(ctx context.Context, tournament *volleynet.TournamentInfo) (
 	*volleynet.Tournament, error) {
 	url := c.getAPITournamentLink(tournament)
 
-	resp, err := http.Get(url)
+	resp, err := c.get(ctx, url)
 
 	if err != nil {
+		c.Diagnostics.Record(scrape.Issue{
+			Kind:         scrape.IssueFailedRequest,
+			Page:         "tournament",
+			TournamentID: tournament.ID,
+			Message:      err.Error(),
+		})
+
 		return nil, errors.Wrapf(err, "loading tournament %d failed", tournament.ID)
 	}
 
-	t, err := scrape.Tournament(resp.Body, time.Now(), tournament)
+	defer resp.Body.Close()
+
+	t, err := scrape.Tournament(resp.Body, time.Now(), tournament, c.Diagnostics)
 
 	if err != nil {
 		return nil, errors.Wrapf(err, "parsing tournament %d failed", tournament.ID)
@@ -159,13 +281,31 @@ func (c *defaultClient) ComplementTournament(tournament *volleynet.TournamentInf
 	return t, nil
 }
 
-func (c *defaultClient) loadUniqueWriteCode(tournamentID int) (string, error) {
+// Matches loads all matches of a tournament from the livescoring page.
+func (c *defaultClient) Matches(ctx context.Context, tournament *volleynet.TournamentInfo) ([]*volleynet.Match, error) {
+	url := c.getLivescoringLink(tournament)
+
+	resp, err := c.get(ctx, url)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading matches of tournament %d failed", tournament.ID)
+	}
+
+	defer resp.Body.Close()
+
+	matches, err := scrape.Matches(resp.Body, tournament)
+
+	return matches, errors.Wrapf(err, "parsing matches of tournament %d failed", tournament.ID)
+}
+
+func (c *defaultClient) loadUniqueWriteCode(ctx context.Context, tournamentID int) (string, error) {
 	url := c.buildPostURL(
 		"/Admin/index.php?screen=Beach/Profile/TurnierAnmeldung&parent=0&prev=0&next=0&cur=%d",
 		tournamentID,
 	).String()
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		url,
 		nil)
@@ -174,30 +314,36 @@ func (c *defaultClient) loadUniqueWriteCode(tournamentID int) (string, error) {
 		return "", errors.Wrap(err, "creating request failed")
 	}
 
-	req.Header.Add("Cookie", c.Cookie)
-
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 
 	if err != nil {
 		return "", errors.Wrap(err, "loading unique writecode failed")
 	}
 
+	defer resp.Body.Close()
+
 	code, err := scrape.UniqueWriteCode(resp.Body)
 
 	return code, errors.Wrap(err, "parsing unique writecode failed")
 }
 
 // WithdrawFromTournament withdraws a player from a tournament.
-// A valid session Cookie must be set.
-func (c *defaultClient) WithdrawFromTournament(tournamentID int) error {
+// The client must be logged in.
+func (c *defaultClient) WithdrawFromTournament(ctx context.Context, tournamentID int) error {
+	if !c.authenticated {
+		return errors.New("client must be logged in")
+	}
+
 	url := c.buildPostURL("/Abmelden/0-%d-00-0", tournamentID).String()
 
-	req, err := http.NewRequest("GET", url, nil)
-	req.Header.Add("Cookie", c.Cookie)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "creating tournamentwithdrawal request failed")
+	}
+
+	// withdrawing changes state, so it is never retried
+	resp, err := c.do(req)
 
 	if err != nil {
 		return errors.Wrapf(err, "tournamentwithdrawal request for tournamentID: %d failed", tournamentID)
@@ -211,19 +357,21 @@ func (c *defaultClient) WithdrawFromTournament(tournamentID int) error {
 			resp.StatusCode)
 	}
 
-	return nil
+	_, err = scrape.Withdrawal(resp.Body, tournamentID, c.Diagnostics)
+
+	return errors.Wrapf(err, "tournamentwithdrawal request for tournamentID: %d failed", tournamentID)
 }
 
 // EnterTournament enters a player at a tournament.
-// A valid session Cookie must be set.
-func (c *defaultClient) EnterTournament( /*playerName string, */ playerID, tournamentID int) error {
-	if c.Cookie == "" {
-		return errors.New("cookie must be set")
+// The client must be logged in.
+func (c *defaultClient) EnterTournament(ctx context.Context, playerID, tournamentID int) error {
+	if !c.authenticated {
+		return errors.New("client must be logged in")
 	}
 
 	form := url.Values{}
 
-	code, err := c.loadUniqueWriteCode(tournamentID)
+	code, err := c.loadUniqueWriteCode(ctx, tournamentID)
 
 	if err != nil {
 		return errors.Wrapf(err, "could not load writecode for tournamentID: %d", tournamentID)
@@ -241,17 +389,15 @@ func (c *defaultClient) EnterTournament( /*playerName string, */ playerID, tourn
 
 	url := c.buildPostURL("/Admin/formular").String()
 
-	req, err := http.NewRequest("POST", url, bytes.NewBufferString(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(form.Encode()))
 
 	if err != nil {
 		return errors.Wrap(err, "creating tournamententry request failed")
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Add("Cookie", c.Cookie)
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	resp, err := c.do(req)
 
 	if err != nil {
 		return errors.Wrapf(err, "tournamententry request for tournamentID: %d failed", tournamentID)
@@ -267,10 +413,14 @@ func (c *defaultClient) EnterTournament( /*playerName string, */ playerID, tourn
 
 	entryData, err := scrape.Entry(resp.Body)
 
-	if err != nil || !entryData.Successfull {
+	if err != nil {
 		return errors.Wrapf(err, "tournamententry request for tournamentID: %d failed", tournamentID)
 	}
 
+	if !entryData.Successfull {
+		return fmt.Errorf("tournamententry request for tournamentID: %d was not confirmed", tournamentID)
+	}
+
 	return nil
 }
 
@@ -287,7 +437,7 @@ func traceResponse(resp *http.Response) {
 }
 
 // SearchPlayers searches for players via firstName, lastName and their birthdate in dd.mm.yyyy format.
-func (c *defaultClient) SearchPlayers(firstName, lastName, birthday string) ([]*scrape.PlayerInfo, error) {
+func (c *defaultClient) SearchPlayers(ctx context.Context, firstName, lastName, birthday string) ([]*scrape.PlayerInfo, error) {
 	form := url.Values{}
 
 	form.Add("XX_unique_write_XXAdmin/Search", "0.50981600 1525795371")
@@ -307,11 +457,13 @@ func (c *defaultClient) SearchPlayers(firstName, lastName, birthday string) ([]*
 
 	url := c.buildPostURL("/Admin/formular")
 
-	response, err := http.PostForm(url.String(), form)
+	response, err := c.postForm(ctx, url.String(), form)
 
 	if err != nil {
 		return nil, err
 	}
 
+	defer response.Body.Close()
+
 	return scrape.Players(response.Body)
 }
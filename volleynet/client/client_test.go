@@ -1,18 +1,50 @@
 package client
 
 import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
 )
 
+var record = flag.Bool("record", false, "record the volleynet.at responses of the replay tests as fixtures")
+var live = flag.Bool("live", false, "run the tests that need access to volleynet.at")
+
+// fixtureDir contains the fixtures of the replay tests. The committed fixtures
+// are not recorded traffic, they were assembled from the hand-written pages in
+// ../testdata (e.g. the withdrawal response is withdrawal.html), so they only
+// check that the client sends the expected requests and parses the pages.
+// Run the tests with `-record` and network access to record real responses.
+const fixtureDir = "../testdata/fixtures"
+
+// newReplayClient returns a client that replays the fixtures, run the
+// tests with `-record` to record new fixtures against volleynet.at.
+func newReplayClient() *defaultClient {
+	mode := ModeReplay
+
+	if *record {
+		mode = ModeRecord
+	}
+
+	return New(
+		WithTransport(NewRecorder(fixtureDir, mode)),
+		WithRetries(0, 0),
+	).(*defaultClient)
+}
+
 func Test_upcoming_games(t *testing.T) {
 	t.Skip()
 
 	c := Default()
-	tournaments, err := c.Tournaments("M", "AMATEUR TOUR", 2018)
+	tournaments, err := c.Tournaments(context.Background(), "M", "AMATEUR TOUR", 2018)
 
 	if err != nil {
 		t.Error(err)
@@ -22,8 +54,12 @@ func Test_upcoming_games(t *testing.T) {
 }
 
 func Test_searchPlayers(t *testing.T) {
+	if !*live {
+		t.Skip("needs access to volleynet.at, run it with -live or see TestReplaySearchPlayers")
+	}
+
 	c := Default()
-	players, err := c.SearchPlayers("Lukas", "Wimmer", "")
+	players, err := c.SearchPlayers(context.Background(), "Lukas", "Wimmer", "")
 
 	if err != nil {
 		t.Error(err)
@@ -43,7 +79,7 @@ func Test_login(t *testing.T) {
 	}
 
 	c := Default()
-	result, err := c.Login(user, password)
+	_, result, err := c.Login(context.Background(), user, password)
 
 	if err != nil {
 		t.Error(err)
@@ -53,3 +89,285 @@ func Test_login(t *testing.T) {
 		t.Error("login(), should return the logged in user")
 	}
 }
+
+func newTestClient(handler http.HandlerFunc) (*defaultClient, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	c := New(WithRetries(2, time.Millisecond)).(*defaultClient)
+	c.GetURL = server.URL
+	c.PostURL = server.URL
+
+	return c, server
+}
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	requests := 0
+
+	c, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	resp, err := c.get(context.Background(), server.URL)
+
+	if err != nil {
+		t.Fatalf("get() err: %v", err)
+	}
+
+	resp.Body.Close()
+
+	if requests != 3 {
+		t.Errorf("get() want 3 requests, got: %d", requests)
+	}
+}
+
+func TestGetGivesUpAfterRetries(t *testing.T) {
+	requests := 0
+
+	c, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	_, err := c.get(context.Background(), server.URL)
+
+	if err == nil {
+		t.Error("get() want err, got nil")
+	}
+
+	if requests != 3 {
+		t.Errorf("get() want 3 requests, got: %d", requests)
+	}
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	requests := 0
+
+	c, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	resp, err := c.get(context.Background(), server.URL)
+
+	if err != nil {
+		t.Fatalf("get() err: %v", err)
+	}
+
+	resp.Body.Close()
+
+	if requests != 1 {
+		t.Errorf("get() want 1 request, got: %d", requests)
+	}
+}
+
+func TestGetCancelled(t *testing.T) {
+	c, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	c.RetryBackoff = time.Hour
+	c.MaxRetryBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.get(ctx, server.URL)
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("get() want err: %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := New(WithRetries(5, time.Second)).(*defaultClient)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		max := time.Second << uint(attempt)
+
+		if max > c.MaxRetryBackoff {
+			max = c.MaxRetryBackoff
+		}
+
+		backoff := c.backoff(attempt)
+
+		if backoff < max/2 || backoff > max {
+			t.Errorf("backoff(%d) want between %s and %s, got: %s", attempt, max/2, max, backoff)
+		}
+	}
+}
+
+func TestReplayTournaments(t *testing.T) {
+	c := newReplayClient()
+
+	tournaments, err := c.Tournaments(context.Background(), "M", "AMATEUR TOUR", 2018)
+
+	if err != nil {
+		t.Fatalf("Tournaments() err: %v", err)
+	}
+
+	if len(tournaments) == 0 {
+		t.Fatal("Tournaments() want len(tournaments) > 0, got 0")
+	}
+
+	if !*record && (len(tournaments) != 4 || tournaments[0].ID != 21908 || tournaments[0].Status != volleynet.StatusUpcoming) {
+		t.Errorf("Tournaments() want 4 tournaments starting with upcoming tournament 21908, got: %d, %+v", len(tournaments), tournaments[0])
+	}
+
+	if !strings.HasPrefix(tournaments[0].Link, c.GetURL+"/beach/bewerbe/") {
+		t.Errorf("Tournaments() want link on host %s, got: %s", c.GetURL, tournaments[0].Link)
+	}
+}
+
+func TestReplayComplementTournament(t *testing.T) {
+	c := newReplayClient()
+
+	tournament, err := c.ComplementTournament(context.Background(), &volleynet.TournamentInfo{
+		ID:     22764,
+		Gender: "M",
+		League: "AMATEUR TOUR",
+		Season: "2018",
+	})
+
+	if err != nil {
+		t.Fatalf("ComplementTournament() err: %v", err)
+	}
+
+	if len(tournament.Teams) == 0 {
+		t.Fatal("ComplementTournament() want teams, got none")
+	}
+
+	if !*record && (len(tournament.Teams) != 10 || tournament.Teams[0].Player1.ID != 22606) {
+		t.Errorf("ComplementTournament() want 10 teams starting with player 22606, got: %d, %+v", len(tournament.Teams), tournament.Teams[0].Player1)
+	}
+
+	expectedLink := c.GetURL + "/beach/bewerbe/AMATEUR%20TOUR/phase/AMATEUR%20TOUR/sex/M/saison/2018/cup/22764"
+
+	if tournament.Link != expectedLink {
+		t.Errorf("ComplementTournament() want link: %s, got: %s", expectedLink, tournament.Link)
+	}
+}
+
+func TestReplayLadder(t *testing.T) {
+	c := newReplayClient()
+
+	players, err := c.Ladder(context.Background(), "M")
+
+	if err != nil {
+		t.Fatalf("Ladder() err: %v", err)
+	}
+
+	if len(players) == 0 {
+		t.Fatal("Ladder() want len(players) > 0, got 0")
+	}
+
+	if !*record && (len(players) != 4 || players[0].ID != 246 || players[0].LadderRank != 1 || players[0].TotalPoints != 2575) {
+		t.Errorf("Ladder() want 4 players starting with player 246 ranked 1st with 2575 points, got: %d, %+v", len(players), players[0])
+	}
+}
+
+func TestReplaySearchPlayers(t *testing.T) {
+	c := newReplayClient()
+
+	players, err := c.SearchPlayers(context.Background(), "Lukas", "Wimmer", "")
+
+	if err != nil {
+		t.Fatalf("SearchPlayers() err: %v", err)
+	}
+
+	if len(players) == 0 {
+		t.Fatal("SearchPlayers() want len(players) > 0, got 0")
+	}
+
+	if !*record && (len(players) != 3 || players[0].ID != 34822) {
+		t.Errorf("SearchPlayers() want 3 players starting with player 34822, got: %d, %+v", len(players), players[0])
+	}
+}
+
+func TestReplayUnknownRequest(t *testing.T) {
+	c := newReplayClient()
+
+	if *record {
+		t.Skip("only replays")
+	}
+
+	_, err := c.SearchPlayers(context.Background(), "Not", "Recorded", "")
+
+	if err == nil {
+		t.Error("SearchPlayers() want err for a request without fixture, got nil")
+	}
+}
+
+func TestReplaySignupAndWithdrawal(t *testing.T) {
+	if *record {
+		t.Skip("signing up for real tournaments must not be recorded automatically")
+	}
+
+	c := newReplayClient()
+	ctx := context.Background()
+
+	session, loginData, err := c.Login(ctx, "raphi011", "password")
+
+	if err != nil {
+		t.Fatalf("Login() err: %v", err)
+	}
+
+	if loginData == nil {
+		t.Error("Login() want login data, got nil")
+	}
+
+	if err = session.EnterTournament(ctx, 28725, 22764); err != nil {
+		t.Errorf("EnterTournament() err: %v", err)
+	}
+
+	if err = session.WithdrawFromTournament(ctx, 22764); err != nil {
+		t.Errorf("WithdrawFromTournament() err: %v", err)
+	}
+
+	if err = c.EnterTournament(ctx, 28725, 22764); err == nil {
+		t.Error("EnterTournament() without login want err, got nil")
+	}
+}
+
+func TestRecordRedactsCookies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "PHPSESSID", Value: "secret-session", Path: "/"})
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(t.TempDir(), ModeRecord)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := recorder.RoundTrip(req)
+
+	if err != nil {
+		t.Fatalf("RoundTrip() err: %v", err)
+	}
+
+	if cookies := resp.Cookies(); len(cookies) != 1 || cookies[0].Value != "secret-session" {
+		t.Errorf("RoundTrip() want the session cookie in the response, got: %v", cookies)
+	}
+
+	recorder.Mode = ModeReplay
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err = recorder.RoundTrip(req)
+
+	if err != nil {
+		t.Fatalf("RoundTrip() err: %v", err)
+	}
+
+	if cookies := resp.Cookies(); len(cookies) != 1 || cookies[0].Value != redactedCookie || cookies[0].Path != "/" {
+		t.Errorf("RoundTrip() want a redacted cookie in the fixture, got: %v", cookies)
+	}
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout         = 30 * time.Second
+	defaultRetries         = 3
+	defaultRetryBackoff    = 500 * time.Millisecond
+	defaultMaxRetryBackoff = 10 * time.Second
+)
+
+// get loads `link` and retries on failure.
+func (c *defaultClient) get(ctx context.Context, link string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", link, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doWithRetry(req)
+}
+
+// postForm posts the url encoded `form` to `link`.
+func (c *defaultClient) postForm(ctx context.Context, link string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", link, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.do(req)
+}
+
+// do sends a request once, it is used for requests
+// that must not be repeated (e.g. form posts).
+func (c *defaultClient) do(req *http.Request) (*http.Response, error) {
+	if err := c.Limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return c.HTTPClient.Do(req)
+}
+
+// doWithRetry sends an idempotent request and retries it with an exponential
+// backoff if the request failed with a network error or a 5xx status code.
+// The request must not have a body.
+func (c *defaultClient) doWithRetry(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.do(req)
+
+		if !shouldRetry(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			// drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+
+			if attempt >= c.Retries {
+				return nil, fmt.Errorf("%s %s failed with code %d", req.Method, req.URL, resp.StatusCode)
+			}
+		} else if attempt >= c.Retries {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+// shouldRetry returns true if the request failed with
+// a network error or the server responded with a 5xx code.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the time to wait before the next attempt, the
+// duration grows exponentially and is randomized by up to 50%
+// so that concurrent requests don't retry at the same time.
+func (c *defaultClient) backoff(attempt int) time.Duration {
+	backoff := c.RetryBackoff << uint(attempt)
+
+	if backoff <= 0 || backoff > c.MaxRetryBackoff {
+		backoff = c.MaxRetryBackoff
+	}
+
+	half := backoff / 2
+
+	if half <= 0 {
+		return backoff
+	}
+
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+	now := l.last
+
+	for i := 0; i < 3; i++ {
+		if wait := l.reserve(now); wait != 0 {
+			t.Errorf("reserve() #%d want no wait, got: %s", i, wait)
+		}
+	}
+
+	if wait := l.reserve(now); wait != time.Second {
+		t.Errorf("reserve() want wait: 1s, got: %s", wait)
+	}
+
+	if wait := l.reserve(now); wait != 2*time.Second {
+		t.Errorf("reserve() want wait: 2s, got: %s", wait)
+	}
+}
+
+func TestRateLimiterRefills(t *testing.T) {
+	l := NewRateLimiter(2, 1)
+	now := l.last
+
+	l.reserve(now)
+
+	if wait := l.reserve(now.Add(500 * time.Millisecond)); wait != 0 {
+		t.Errorf("reserve() want no wait, got: %s", wait)
+	}
+
+	// the bucket never holds more than `burst` tokens
+	now = now.Add(time.Hour)
+	l.reserve(now)
+
+	if wait := l.reserve(now); wait != 500*time.Millisecond {
+		t.Errorf("reserve() want wait: 500ms, got: %s", wait)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l := NewRateLimiter(0.001, 1)
+	l.reserve(time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait() want err: %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestNilRateLimiter(t *testing.T) {
+	var l *RateLimiter
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() err: %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RecorderMode defines if a Recorder records or replays responses.
+type RecorderMode int
+
+const (
+	// ModeReplay serves responses from the fixtures and fails
+	// requests that have not been recorded.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends requests to the server and saves the responses as fixtures.
+	ModeRecord
+)
+
+// Recorder is a http.RoundTripper that records responses as fixtures and
+// replays them, this allows to test the client without network access.
+// Fixtures are stored as raw http responses, one file per request, the
+// values of the cookies that the server sets are not stored.
+type Recorder struct {
+	Dir  string
+	Mode RecorderMode
+
+	// Transport sends the requests in record mode, defaults to http.DefaultTransport
+	Transport http.RoundTripper
+
+	// Redact contains form fields whose values are neither
+	// stored nor used to match requests (e.g. passwords).
+	Redact []string
+}
+
+// NewRecorder creates a Recorder that stores its fixtures in `dir`.
+func NewRecorder(dir string, mode RecorderMode) *Recorder {
+	return &Recorder{
+		Dir:    dir,
+		Mode:   mode,
+		Redact: []string{"login_pass"},
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(r.Dir, r.fixtureName(req, body))
+
+	if r.Mode == ModeRecord {
+		return r.record(req, path)
+	}
+
+	return r.replay(req, path)
+}
+
+func (r *Recorder) record(req *http.Request, path string) (*http.Response, error) {
+	transport := r.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "reading response failed")
+	}
+
+	// store the body as is so the fixtures stay readable
+	resp.ContentLength = int64(len(body))
+	resp.TransferEncoding = nil
+	resp.Header.Del("Transfer-Encoding")
+
+	// the fixture doesn't contain the session cookies, the
+	// response keeps them so the recorded session still works
+	fixture := *resp
+	fixture.Header = redactCookies(resp.Header)
+	fixture.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	raw, err := httputil.DumpResponse(&fixture, true)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "dumping response failed")
+	}
+
+	if err = os.MkdirAll(r.Dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "creating fixture directory failed")
+	}
+
+	if err = ioutil.WriteFile(path, raw, 0644); err != nil {
+		return nil, errors.Wrap(err, "saving fixture failed")
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// redactedCookie replaces the values of the cookies that are stored in fixtures.
+const redactedCookie = "redacted"
+
+// redactCookies returns a copy of `header` whose `Set-Cookie`
+// headers keep the cookie names and attributes but not the values.
+func redactCookies(header http.Header) http.Header {
+	redacted := header.Clone()
+	cookies := redacted["Set-Cookie"]
+
+	for i, cookie := range cookies {
+		nameEnd := strings.Index(cookie, "=")
+
+		if nameEnd == -1 {
+			continue
+		}
+
+		attributes := ""
+
+		if attributesStart := strings.Index(cookie, ";"); attributesStart != -1 {
+			attributes = cookie[attributesStart:]
+		}
+
+		cookies[i] = cookie[:nameEnd+1] + redactedCookie + attributes
+	}
+
+	return redacted
+}
+
+func (r *Recorder) replay(req *http.Request, path string) (*http.Response, error) {
+	raw, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no fixture recorded for %s %s (%s)", req.Method, req.URL, filepath.Base(path))
+	} else if err != nil {
+		return nil, errors.Wrap(err, "loading fixture failed")
+	}
+
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(raw)), req)
+}
+
+// readRequestBody reads the body of `req` and replaces
+// it so the request can still be sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "reading request body failed")
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+var nonAlphanumericRegex = regexp.MustCompile("[^a-zA-Z0-9]+")
+
+const maxFixtureNameLength = 80
+
+// fixtureName returns a readable file name that is unique for the
+// method, path, query and (redacted) form values of a request.
+func (r *Recorder) fixtureName(req *http.Request, body []byte) string {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		body = []byte(r.redactForm(string(body)))
+	}
+
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s %s\n", req.Method, req.URL.RequestURI())
+	hash.Write(body)
+
+	name := strings.Trim(nonAlphanumericRegex.ReplaceAllString(req.URL.Path, "-"), "-")
+
+	if len(name) > maxFixtureNameLength {
+		name = name[:maxFixtureNameLength]
+	}
+
+	return fmt.Sprintf("%s-%s-%s.http",
+		strings.ToLower(req.Method),
+		name,
+		hex.EncodeToString(hash.Sum(nil))[:8],
+	)
+}
+
+// redactForm removes the values of all `Redact` fields and sorts the
+// form by key so the order of the fields doesn't matter.
+func (r *Recorder) redactForm(body string) string {
+	form, err := url.ParseQuery(body)
+
+	if err != nil {
+		return body
+	}
+
+	for _, field := range r.Redact {
+		if _, ok := form[field]; ok {
+			form.Set(field, "")
+		}
+	}
+
+	return form.Encode()
+}
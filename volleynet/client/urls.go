@@ -85,3 +85,10 @@ func (c *defaultClient) getAPITournamentLink(t *volleynet.TournamentInfo) string
 
 	return url.String()
 }
+
+// getLivescoringLink returns the link to the livescoring page of a tournament.
+func (c *defaultClient) getLivescoringLink(t *volleynet.TournamentInfo) string {
+	url := c.buildGetURL("/livescore/bewerb/%d", t.ID)
+
+	return url.String()
+}
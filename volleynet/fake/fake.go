@@ -0,0 +1,383 @@
+// Package fake implements a fake volleynet.at that serves pages rendered
+// from an in-memory state, this allows to run the api and tests offline.
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+type account struct {
+	password string
+	playerID int
+}
+
+// Server is a fake volleynet.at, signups and withdrawals change
+// the in-memory state and show up in the following requests.
+type Server struct {
+	mutex sync.Mutex
+
+	tournaments map[int]*volleynet.Tournament
+	players     map[int]*volleynet.Player
+	clubs       map[int][]*volleynet.PlayerClub // the club history of the players
+	matches     map[int][]*volleynet.Match      // the matches of the tournaments
+	accounts    map[string]account
+	sessions    map[string]int // maps the session id to the logged in player
+
+	templates *template.Template
+}
+
+// New creates an empty Server which renders the pages
+// with the templates found in `templateDir`.
+func New(templateDir string) (*Server, error) {
+	templates, err := template.New("").Funcs(templateFuncs).ParseGlob(filepath.Join(templateDir, "*.html"))
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing templates failed")
+	}
+
+	return &Server{
+		tournaments: make(map[int]*volleynet.Tournament),
+		players:     make(map[int]*volleynet.Player),
+		clubs:       make(map[int][]*volleynet.PlayerClub),
+		matches:     make(map[int][]*volleynet.Match),
+		accounts:    make(map[string]account),
+		sessions:    make(map[string]int),
+		templates:   templates,
+	}, nil
+}
+
+// AddTournaments adds or replaces tournaments.
+func (s *Server) AddTournaments(tournaments ...*volleynet.Tournament) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, t := range tournaments {
+		if t.Teams == nil {
+			t.Teams = []*volleynet.TournamentTeam{}
+		}
+
+		s.tournaments[t.ID] = t
+	}
+}
+
+// AddPlayers adds or replaces players.
+func (s *Server) AddPlayers(players ...*volleynet.Player) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, p := range players {
+		s.players[p.ID] = p
+	}
+}
+
+// AddClubs adds clubs to the club history of the players, a club
+// of a season that is already known replaces it.
+func (s *Server) AddClubs(clubs ...*volleynet.PlayerClub) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, c := range clubs {
+		s.clubs[c.PlayerID] = append(withoutSeason(s.clubs[c.PlayerID], c.Season), c)
+	}
+}
+
+func withoutSeason(clubs []*volleynet.PlayerClub, season string) []*volleynet.PlayerClub {
+	filtered := []*volleynet.PlayerClub{}
+
+	for _, c := range clubs {
+		if c.Season != season {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return filtered
+}
+
+// AddMatches adds or replaces matches, they show up on
+// the livescoring page of their tournament.
+func (s *Server) AddMatches(matches ...*volleynet.Match) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, m := range matches {
+		s.matches[m.TournamentID] = append(withoutMatchNr(s.matches[m.TournamentID], m.MatchNr), m)
+	}
+}
+
+func withoutMatchNr(matches []*volleynet.Match, matchNr int) []*volleynet.Match {
+	filtered := []*volleynet.Match{}
+
+	for _, m := range matches {
+		if m.MatchNr != matchNr {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
+}
+
+// AddAccount allows the player with `playerID` to login.
+func (s *Server) AddAccount(username, password string, playerID int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.accounts[username] = account{password: password, playerID: playerID}
+}
+
+// Tournament returns a copy of a tournament.
+func (s *Server) Tournament(tournamentID int) (*volleynet.Tournament, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	t, ok := s.tournaments[tournamentID]
+
+	if !ok {
+		return nil, false
+	}
+
+	copy := *t
+	copy.Teams = append([]*volleynet.TournamentTeam{}, t.Teams...)
+
+	return &copy, true
+}
+
+// tournamentList returns all tournaments of a league, gender and season ordered by their start date.
+func (s *Server) tournamentList(league, gender, season string) []*volleynet.Tournament {
+	tournaments := []*volleynet.Tournament{}
+
+	for _, t := range s.tournaments {
+		if strings.EqualFold(t.League, league) && t.Gender == gender && t.Season == season {
+			tournaments = append(tournaments, t)
+		}
+	}
+
+	sort.Slice(tournaments, func(i, j int) bool {
+		if tournaments[i].Start.Equal(tournaments[j].Start) {
+			return tournaments[i].ID < tournaments[j].ID
+		}
+
+		return tournaments[i].Start.Before(tournaments[j].Start)
+	})
+
+	return tournaments
+}
+
+// clubHistory returns the clubs of a player, the latest season first.
+func (s *Server) clubHistory(playerID int) []*volleynet.PlayerClub {
+	clubs := append([]*volleynet.PlayerClub{}, s.clubs[playerID]...)
+
+	sort.Slice(clubs, func(i, j int) bool {
+		return clubs[i].Season > clubs[j].Season
+	})
+
+	return clubs
+}
+
+// tournamentMatches returns the matches of a tournament ordered by their number.
+func (s *Server) tournamentMatches(tournamentID int) []*volleynet.Match {
+	matches := append([]*volleynet.Match{}, s.matches[tournamentID]...)
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].MatchNr < matches[j].MatchNr
+	})
+
+	return matches
+}
+
+// ladder returns all ranked players of a gender ordered by their rank.
+func (s *Server) ladder(gender string) []*volleynet.Player {
+	players := []*volleynet.Player{}
+
+	for _, p := range s.players {
+		if p.Gender == gender && p.LadderRank > 0 {
+			players = append(players, p)
+		}
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].LadderRank < players[j].LadderRank
+	})
+
+	return players
+}
+
+// searchPlayers returns all players whose names start with
+// `firstName` and `lastName` and that have a known birthday.
+func (s *Server) searchPlayers(firstName, lastName string) []*volleynet.Player {
+	players := []*volleynet.Player{}
+
+	for _, p := range s.players {
+		if p.Birthday == nil ||
+			!hasPrefixFold(p.FirstName, firstName) ||
+			!hasPrefixFold(p.LastName, lastName) {
+			continue
+		}
+
+		players = append(players, p)
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].ID < players[j].ID
+	})
+
+	return players
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// login creates a new session if the credentials are valid.
+func (s *Server) login(username, password string) (sessionID string, player *volleynet.Player, ok bool) {
+	acc, ok := s.accounts[username]
+
+	if !ok || acc.password != password {
+		return "", nil, false
+	}
+
+	player, ok = s.players[acc.playerID]
+
+	if !ok {
+		return "", nil, false
+	}
+
+	sessionID = randomHex(16)
+	s.sessions[sessionID] = player.ID
+
+	return sessionID, player, true
+}
+
+// enter signs up the player with a partner for a tournament.
+func (s *Server) enter(playerID, partnerID, tournamentID int) error {
+	t, ok := s.tournaments[tournamentID]
+
+	if !ok {
+		return fmt.Errorf("Turnier %d existiert nicht", tournamentID)
+	}
+
+	if !t.RegistrationOpen {
+		return errors.New("Die Nennfrist ist abgelaufen")
+	}
+
+	player := s.players[playerID]
+	partner, ok := s.players[partnerID]
+
+	if !ok {
+		return fmt.Errorf("Spieler %d existiert nicht", partnerID)
+	}
+
+	for _, team := range t.Teams {
+		if team.Deregistered {
+			continue
+		}
+
+		if isInTeam(team, playerID) || isInTeam(team, partnerID) {
+			return errors.New("Ein Spieler ist bereits angemeldet")
+		}
+	}
+
+	t.Teams = append(t.Teams, &volleynet.TournamentTeam{
+		TournamentID: t.ID,
+		Player1:      player,
+		Player2:      partner,
+		TotalPoints:  player.TotalPoints + partner.TotalPoints,
+	})
+	t.SignedupTeams++
+
+	return nil
+}
+
+// withdraw marks the team of the player as deregistered.
+func (s *Server) withdraw(playerID, tournamentID int) error {
+	t, ok := s.tournaments[tournamentID]
+
+	if !ok {
+		return fmt.Errorf("Turnier %d existiert nicht", tournamentID)
+	}
+
+	for _, team := range t.Teams {
+		if !team.Deregistered && isInTeam(team, playerID) {
+			team.Deregistered = true
+			t.SignedupTeams--
+
+			return nil
+		}
+	}
+
+	return errors.New("Sie sind für dieses Turnier nicht angemeldet")
+}
+
+func isInTeam(team *volleynet.TournamentTeam, playerID int) bool {
+	return team.Player1.ID == playerID || team.Player2.ID == playerID
+}
+
+func randomHex(bytes int) string {
+	b := make([]byte, bytes)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+var templateFuncs = template.FuncMap{
+	"inc": func(i int) int {
+		return i + 1
+	},
+	"upper": strings.ToUpper,
+	"date": func(d interface{}) string {
+		switch date := d.(type) {
+		case time.Time:
+			return date.Format("02.01.2006")
+		case *time.Time:
+			if date == nil {
+				return ""
+			}
+
+			return date.Format("02.01.2006")
+		}
+
+		return ""
+	},
+	"dates": func(start, end time.Time) string {
+		if start.Equal(end) {
+			return start.Format("02.01.2006")
+		}
+
+		return start.Format("02.01.2006") + " - " + end.Format("02.01.2006")
+	},
+	"result": func(m *volleynet.Match) string {
+		sets := []string{}
+
+		for _, set := range []volleynet.MatchSet{m.Set1, m.Set2, m.Set3} {
+			if set.Team1 != 0 || set.Team2 != 0 {
+				sets = append(sets, fmt.Sprintf("%d:%d", set.Team1, set.Team2))
+			}
+		}
+
+		return strings.Join(sets, ", ")
+	},
+	"tournamentLink": func(t *volleynet.Tournament) string {
+		return fmt.Sprintf("beach/bewerbe/%s/phase/%s/sex/%s/saison/%s/cup/%d",
+			url.PathEscape(t.League),
+			url.PathEscape(t.SubLeague),
+			t.Gender,
+			t.Season,
+			t.ID,
+		)
+	},
+}
@@ -0,0 +1,146 @@
+package fake
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+	"github.com/raphi011/scores-api/volleynet/client"
+)
+
+func setupFake(t *testing.T) (*Server, client.Client) {
+	t.Helper()
+
+	s, err := Load("../testdata", time.Now().Year()+1)
+	test.Check(t, "Load() err: %v", err)
+
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	c := client.New(client.WithURLs(server.URL, server.URL), client.WithRetries(0, 0))
+
+	return s, c
+}
+
+func TestTournaments(t *testing.T) {
+	_, c := setupFake(t)
+	ctx := context.Background()
+
+	tournaments, err := c.Tournaments(ctx, "M", "AMATEUR TOUR", time.Now().Year()+1)
+	test.Check(t, "Tournaments() err: %v", err)
+	test.Assert(t, "Tournaments() want tournaments, got none", len(tournaments) > 0)
+
+	tournament, err := c.ComplementTournament(ctx, tournaments[0])
+	test.Check(t, "ComplementTournament() err: %v", err)
+	test.Equal(t, "ComplementTournament() want .Name: %q, got: %q", tournaments[0].Name, tournament.Name)
+}
+
+func TestLadder(t *testing.T) {
+	_, c := setupFake(t)
+
+	players, err := c.Ladder(context.Background(), "M")
+	test.Check(t, "Ladder() err: %v", err)
+	test.Assert(t, "Ladder() want players, got none", len(players) > 0)
+}
+
+func TestSearchPlayers(t *testing.T) {
+	_, c := setupFake(t)
+
+	players, err := c.SearchPlayers(context.Background(), "Richard", "Bos", "")
+	test.Check(t, "SearchPlayers() err: %v", err)
+	test.Assert(t, "SearchPlayers() want 1 player, got: %d", len(players) == 1, len(players))
+}
+
+func TestPlayerProfile(t *testing.T) {
+	_, c := setupFake(t)
+
+	profile, err := c.PlayerProfile(context.Background(), 22606)
+	test.Check(t, "PlayerProfile() err: %v", err)
+	test.Equal(t, "PlayerProfile() want .LastName: %q, got: %q", "Bosse", profile.LastName)
+	test.Equal(t, "PlayerProfile() want .Club: %q, got: %q", "1. Stockerauer Beachvolleyballverein", profile.Club)
+	test.Equal(t, "PlayerProfile() want .License.Type: %q, got: %q", "A", profile.License.Type)
+	test.Assert(t, "PlayerProfile() want 3 clubs, got: %d", len(profile.Clubs) == 3, len(profile.Clubs))
+	test.Equal(t, "PlayerProfile() want latest .Season: %q, got: %q", "2018", profile.Clubs[0].Season)
+}
+
+func TestMatches(t *testing.T) {
+	s, c := setupFake(t)
+	info := &volleynet.TournamentInfo{ID: 22764, Gender: "M"}
+
+	matches, err := c.Matches(context.Background(), info)
+	test.Check(t, "Matches() err: %v", err)
+	test.Assert(t, "Matches() want matches, got none", len(matches) > 0)
+
+	first := matches[0]
+	test.Equal(t, "Matches() want .Team1.Player1.ID: %d, got: %d", 22606, first.Team1.Player1.ID)
+	test.Equal(t, "Matches() want .Set1: %v, got: %v", volleynet.MatchSet{Team1: 21, Team2: 15}, first.Set1)
+	test.Equal(t, "Matches() want .Winner: %d, got: %d", 1, first.Winner)
+
+	s.AddMatches(&volleynet.Match{
+		TournamentID: 22764,
+		MatchNr:      first.MatchNr,
+		Round:        first.Round,
+		Court:        first.Court,
+		Team1:        first.Team1,
+		Team2:        first.Team2,
+		Set1:         volleynet.MatchSet{Team1: 15, Team2: 21},
+		Set2:         volleynet.MatchSet{Team1: 17, Team2: 21},
+		Winner:       2,
+	})
+
+	after, err := c.Matches(context.Background(), info)
+	test.Check(t, "Matches() err: %v", err)
+	test.Equal(t, "AddMatches() want %d matches, got: %d", len(matches), len(after))
+	test.Equal(t, "AddMatches() want replaced .Winner: %d, got: %d", 2, after[0].Winner)
+}
+
+func TestLoginFailed(t *testing.T) {
+	s, c := setupFake(t)
+	s.AddAccount("bosse", "secret", 22606)
+
+	_, _, err := c.Login(context.Background(), "bosse", "wrong")
+	test.Assert(t, "Login() want err, got nil", err != nil)
+}
+
+func TestSignupAndWithdrawal(t *testing.T) {
+	s, c := setupFake(t)
+	s.AddPlayers(
+		&volleynet.Player{ID: 1, FirstName: "Max", LastName: "Mustermann", Gender: "M"},
+		&volleynet.Player{ID: 2, FirstName: "Hans", LastName: "Huber", Gender: "M"},
+	)
+	s.AddAccount("max", "secret", 1)
+	ctx := context.Background()
+
+	info := &volleynet.TournamentInfo{ID: 22764, Gender: "M", League: "AMATEUR TOUR", Season: "2018"}
+
+	before, err := c.ComplementTournament(ctx, info)
+	test.Check(t, "ComplementTournament() err: %v", err)
+
+	session, loginData, err := c.Login(ctx, "max", "secret")
+	test.Check(t, "Login() err: %v", err)
+	test.Equal(t, "Login() want .ID: %d, got: %d", 1, loginData.ID)
+
+	err = session.EnterTournament(ctx, 2, 22764)
+	test.Check(t, "EnterTournament() err: %v", err)
+
+	err = session.EnterTournament(ctx, 2, 22764)
+	test.Assert(t, "EnterTournament() twice want err, got nil", err != nil)
+
+	after, err := c.ComplementTournament(ctx, info)
+	test.Check(t, "ComplementTournament() err: %v", err)
+	test.Equal(t, "EnterTournament() want %d signed up teams, got: %d", before.SignedupTeams+1, after.SignedupTeams)
+
+	err = session.WithdrawFromTournament(ctx, 22764)
+	test.Check(t, "WithdrawFromTournament() err: %v", err)
+
+	after, err = c.ComplementTournament(ctx, info)
+	test.Check(t, "ComplementTournament() err: %v", err)
+
+	team := after.Teams[len(after.Teams)-1]
+
+	test.Assert(t, "WithdrawFromTournament() want team to be deregistered", team.Deregistered)
+	test.Equal(t, "WithdrawFromTournament() want %d signed up teams, got: %d", before.SignedupTeams, after.SignedupTeams)
+}
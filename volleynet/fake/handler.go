@@ -0,0 +1,260 @@
+package fake
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+const sessionCookie = "PHPSESSID"
+
+// loginPlayer is shown after a successful login.
+type loginPlayer struct {
+	*volleynet.Player
+
+	Season int
+}
+
+// ServeHTTP serves the volleynet.at endpoints that are used by the client.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	path := r.URL.Path
+
+	switch {
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/beach/bewerbe/Rangliste/phase/"):
+		s.getLadder(w, r)
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/beach/bewerbe/"):
+		s.getTournaments(w, r)
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/beach/information/steckbrief-"):
+		s.getProfile(w, r)
+	case r.Method == "GET" && strings.HasPrefix(path, "/livescore/bewerb/"):
+		s.getLivescoring(w, r)
+	case r.Method == "GET" && path == "/Admin/index.php":
+		s.getSignup(w, r)
+	case r.Method == "GET" && strings.HasPrefix(path, "/Abmelden/"):
+		s.getWithdrawal(w, r)
+	case r.Method == "POST" && path == "/Admin/formular":
+		s.postForm(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// pathParts maps the keys of a path like `/bewerbe/{league}/phase/{phase}` to their values.
+func pathParts(path string) map[string]string {
+	parts := map[string]string{}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := 0; i+1 < len(segments); i++ {
+		parts[segments[i]] = segments[i+1]
+	}
+
+	return parts
+}
+
+func (s *Server) getLadder(w http.ResponseWriter, r *http.Request) {
+	title := pathParts(r.URL.Path)["phase"]
+	gender := "M"
+
+	if title == "Damen" {
+		gender = "W"
+	}
+
+	s.render(w, "ladder.html", struct {
+		Title   string
+		Players interface{}
+	}{title, s.ladder(gender)})
+}
+
+func (s *Server) getTournaments(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r.URL.Path)
+
+	if cup, ok := parts["cup"]; ok {
+		id, _ := strconv.Atoi(cup)
+		t, ok := s.tournaments[id]
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		s.render(w, "tournament.html", t)
+		return
+	}
+
+	s.render(w, "tournament-list.html", struct {
+		League      string
+		Tournaments interface{}
+	}{parts["bewerbe"], s.tournamentList(parts["bewerbe"], parts["sex"], parts["saison"])})
+}
+
+func (s *Server) getProfile(w http.ResponseWriter, r *http.Request) {
+	playerID, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/beach/information/steckbrief-"))
+	player, ok := s.players[playerID]
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.render(w, "profile.html", struct {
+		*volleynet.Player
+
+		Season int
+		Clubs  []*volleynet.PlayerClub
+	}{player, time.Now().Year(), s.clubHistory(playerID)})
+}
+
+func (s *Server) getLivescoring(w http.ResponseWriter, r *http.Request) {
+	tournamentID, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/livescore/bewerb/"))
+	t, ok := s.tournaments[tournamentID]
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.render(w, "livescoring.html", struct {
+		*volleynet.Tournament
+
+		Matches []*volleynet.Match
+	}{t, s.tournamentMatches(tournamentID)})
+}
+
+// session returns the id of the logged in player.
+func (s *Server) session(r *http.Request) (int, bool) {
+	cookie, err := r.Cookie(sessionCookie)
+
+	if err != nil {
+		return 0, false
+	}
+
+	playerID, ok := s.sessions[cookie.Value]
+
+	return playerID, ok
+}
+
+func (s *Server) getSignup(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.session(r); !ok {
+		http.Error(w, "Bitte melden Sie sich an", http.StatusUnauthorized)
+		return
+	}
+
+	tournamentID, _ := strconv.Atoi(r.URL.Query().Get("cur"))
+
+	s.render(w, "signup.html", struct {
+		Code         string
+		TournamentID int
+	}{writeCode(), tournamentID})
+}
+
+func (s *Server) getWithdrawal(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := s.session(r)
+
+	if !ok {
+		http.Error(w, "Bitte melden Sie sich an", http.StatusUnauthorized)
+		return
+	}
+
+	// the path has the format `/Abmelden/{playerID}-{tournamentID}-00-{teamID}`
+	ids := strings.Split(strings.TrimPrefix(r.URL.Path, "/Abmelden/"), "-")
+
+	if len(ids) != 4 {
+		http.NotFound(w, r)
+		return
+	}
+
+	tournamentID, _ := strconv.Atoi(ids[1])
+
+	s.renderResult(w, "withdrawal.html", s.withdraw(playerID, tournamentID))
+}
+
+func (s *Server) postForm(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch r.PostForm.Get("action") {
+	case "Beach/Profile/ProfileLogin":
+		s.postLogin(w, r)
+	case "Admin/Search":
+		s.render(w, "search.html", s.searchPlayers(
+			r.PostForm.Get("per_vorname"),
+			r.PostForm.Get("per_name"),
+		))
+	case "Beach/Profile/TurnierAnmeldung":
+		s.postEntry(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) postLogin(w http.ResponseWriter, r *http.Request) {
+	sessionID, player, ok := s.login(r.PostForm.Get("login_name"), r.PostForm.Get("login_pass"))
+
+	if !ok {
+		http.Error(w, "Benutzername oder Passwort falsch", http.StatusUnauthorized)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: sessionID, Path: "/"})
+
+	s.render(w, "login.html", &loginPlayer{Player: player, Season: time.Now().Year()})
+}
+
+func (s *Server) postEntry(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := s.session(r)
+
+	if !ok {
+		http.Error(w, "Bitte melden Sie sich an", http.StatusUnauthorized)
+		return
+	}
+
+	if r.PostForm.Get("XX_unique_write_XXBeach/Profile/TurnierAnmeldung") == "" {
+		http.Error(w, "Ungültiges Formular", http.StatusBadRequest)
+		return
+	}
+
+	partnerID, _ := strconv.Atoi(r.PostForm.Get("bte_per_id_b"))
+	tournamentID, _ := strconv.Atoi(r.PostForm.Get("cur"))
+
+	s.renderResult(w, "entry.html", s.enter(playerID, partnerID, tournamentID))
+}
+
+func (s *Server) renderResult(w http.ResponseWriter, name string, err error) {
+	result := struct {
+		Successfull bool
+		Code        string
+		Message     string
+	}{Successfull: err == nil, Code: writeCode()}
+
+	if err != nil {
+		result.Message = err.Error()
+	}
+
+	s.render(w, name, result)
+}
+
+func (s *Server) render(w http.ResponseWriter, name string, data interface{}) {
+	var buf bytes.Buffer
+
+	if err := s.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	w.Write(buf.Bytes())
+}
+
+// writeCode returns a XSRF token in the format volleynet.at uses.
+func writeCode() string {
+	return "0." + strconv.FormatInt(time.Now().UnixNano()%100000000, 10) + " " + strconv.FormatInt(time.Now().Unix(), 10)
+}
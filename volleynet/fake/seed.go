@@ -0,0 +1,228 @@
+package fake
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
+	"github.com/raphi011/scores-api/volleynet/scrape"
+)
+
+// seedSeason is the season of the pages in the testdata directory.
+const seedSeason = 2018
+
+var seedTournamentLists = []string{"tournament-list-amateur.html", "tournament-list-pro.html"}
+
+// seedTournamentDetails contains the pages of tournaments that have teams.
+var seedTournamentDetails = map[string]volleynet.TournamentInfo{
+	"22764-upcoming-firstteams.html": {
+		ID:           22764,
+		Name:         "Herren Stockerau",
+		Season:       "2018",
+		League:       "AMATEUR TOUR",
+		LeagueKey:    "amateur-tour",
+		SubLeague:    "ABV Tour AMATEUR 1",
+		SubLeagueKey: "abv-tour-amateur-1",
+		Gender:       "M",
+		Status:       volleynet.StatusUpcoming,
+	},
+}
+
+// seedProfiles contains the profile (steckbrief) pages of players.
+var seedProfiles = map[string]int{
+	"steckbrief-22606.html": 22606,
+}
+
+// seedMatches contains the livescoring pages of tournaments.
+var seedMatches = map[string]volleynet.TournamentInfo{
+	"22764-livescoring.html": {ID: 22764, Gender: "M"},
+}
+
+// Load creates a Server with the pages found in the `testdata` directory, the
+// tournaments are moved to `season` and registration is open for all
+// tournaments that have not started yet.
+func Load(testdata string, season int) (*Server, error) {
+	s, err := New(filepath.Join(testdata, "fake"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := parseFile(filepath.Join(testdata, "ladder-men.html"), func(f *os.File) (interface{}, error) {
+		return scrape.Ladder(f, nil)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.AddPlayers(players.([]*volleynet.Player)...)
+
+	tournaments := []*volleynet.Tournament{}
+
+	for _, name := range seedTournamentLists {
+		list, err := parseFile(filepath.Join(testdata, name), func(f *os.File) (interface{}, error) {
+			return scrape.TournamentList(f, "", nil)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range list.([]*volleynet.TournamentInfo) {
+			tournaments = append(tournaments, defaultTournament(info))
+		}
+	}
+
+	for name, info := range seedTournamentDetails {
+		info := info
+
+		t, err := parseFile(filepath.Join(testdata, name), func(f *os.File) (interface{}, error) {
+			// parse it as if it was the day of the signup
+			return scrape.Tournament(f, time.Date(seedSeason, time.January, 1, 0, 0, 0, 0, time.UTC), &info, nil)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		tournament := t.(*volleynet.Tournament)
+
+		for _, team := range tournament.Teams {
+			s.addMissingPlayers(team.Player1, team.Player2)
+		}
+
+		tournaments = append(tournaments, tournament)
+	}
+
+	now := time.Now()
+
+	for _, t := range tournaments {
+		moveToSeason(t, season)
+
+		if t.Status != volleynet.StatusCanceled {
+			t.Status = volleynet.StatusUpcoming
+			t.RegistrationOpen = t.Start.After(now)
+		}
+	}
+
+	s.AddTournaments(tournaments...)
+
+	for name, playerID := range seedProfiles {
+		if err := s.seedProfile(filepath.Join(testdata, name), playerID); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, info := range seedMatches {
+		info := info
+
+		matches, err := parseFile(filepath.Join(testdata, name), func(f *os.File) (interface{}, error) {
+			return scrape.Matches(f, &info)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range matches.([]*volleynet.Match) {
+			s.addMissingPlayers(m.Team1.Player1, m.Team1.Player2, m.Team2.Player1, m.Team2.Player2)
+		}
+
+		s.AddMatches(matches.([]*volleynet.Match)...)
+	}
+
+	// the search page needs a birthday which the ladder doesn't show
+	for _, p := range s.players {
+		if p.Birthday == nil {
+			birthday := time.Date(1970+p.ID%30, time.Month(1+p.ID%12), 1+p.ID%28, 0, 0, 0, 0, time.UTC)
+			p.Birthday = &birthday
+		}
+	}
+
+	return s, nil
+}
+
+func parseFile(path string, parse func(*os.File) (interface{}, error)) (interface{}, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s failed", path)
+	}
+
+	defer f.Close()
+
+	result, err := parse(f)
+
+	return result, errors.Wrapf(err, "parsing %s failed", path)
+}
+
+// seedProfile adds the details of a profile page to the player.
+func (s *Server) seedProfile(path string, playerID int) error {
+	p, err := parseFile(path, func(f *os.File) (interface{}, error) {
+		return scrape.PlayerProfile(f, playerID)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	profile := p.(*scrape.ProfileData)
+
+	player, ok := s.players[playerID]
+
+	if !ok {
+		return errors.Errorf("player %d of %s is not part of the ladder or a tournament", playerID, path)
+	}
+
+	birthday := profile.Birthday
+
+	player.FirstName = profile.FirstName
+	player.LastName = profile.LastName
+	player.Birthday = &birthday
+	player.Club = profile.Club
+	player.CountryUnion = profile.CountryUnion
+	player.License = profile.License.Type
+
+	s.AddClubs(profile.Clubs...)
+
+	return nil
+}
+
+func (s *Server) addMissingPlayers(players ...*volleynet.Player) {
+	for _, p := range players {
+		if _, ok := s.players[p.ID]; !ok {
+			s.players[p.ID] = p
+		}
+	}
+}
+
+// defaultTournament adds the details that are
+// missing in the tournament list to a tournament.
+func defaultTournament(info *volleynet.TournamentInfo) *volleynet.Tournament {
+	return &volleynet.Tournament{
+		TournamentInfo: *info,
+		Location:       info.Name,
+		Mode:           "Double Elimination 16er-Raster",
+		MaxTeams:       16,
+		MinTeams:       4,
+		Teams:          []*volleynet.TournamentTeam{},
+	}
+}
+
+func moveToSeason(t *volleynet.Tournament, season int) {
+	years := season - seedSeason
+
+	t.Season = strconv.Itoa(season)
+	t.Start = t.Start.AddDate(years, 0, 0)
+	t.End = t.End.AddDate(years, 0, 0)
+
+	if t.EndRegistration != nil {
+		endRegistration := t.EndRegistration.AddDate(years, 0, 0)
+		t.EndRegistration = &endRegistration
+	}
+}
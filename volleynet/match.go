@@ -0,0 +1,32 @@
+package volleynet
+
+import "github.com/raphi011/scores-api"
+
+// MatchTeam is one of the two teams playing a match.
+type MatchTeam struct {
+	Player1 *Player `json:"player1"`
+	Player2 *Player `json:"player2"`
+}
+
+// MatchSet contains the points both teams scored in a set.
+type MatchSet struct {
+	Team1 int `json:"team1" db:"team_1"`
+	Team2 int `json:"team2" db:"team_2"`
+}
+
+// Match is a single game between two teams of a tournament,
+// it is parsed from the livescoring page.
+type Match struct {
+	scores.Track
+
+	TournamentID int        `json:"tournamentId" db:"tournament_id"`
+	MatchNr      int        `json:"matchNr" db:"match_nr"`
+	Round        string     `json:"round"`
+	Court        int        `json:"court"`
+	Team1        *MatchTeam `json:"team1" db:"team_1"`
+	Team2        *MatchTeam `json:"team2" db:"team_2"`
+	Set1         MatchSet   `json:"set1" db:"set_1"`
+	Set2         MatchSet   `json:"set2" db:"set_2"`
+	Set3         MatchSet   `json:"set3" db:"set_3"`
+	Winner       int        `json:"winner"` // 1 if `Team1` won, 2 if `Team2` won and 0 if it has not been played yet
+}
@@ -1,7 +1,10 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/raphi011/scores-api/volleynet"
+	"github.com/raphi011/scores-api/volleynet/client"
 	"github.com/raphi011/scores-api/volleynet/scrape"
 	"github.com/stretchr/testify/mock"
 )
@@ -10,43 +13,57 @@ type ClientMock struct {
 	mock.Mock
 }
 
-func (m *ClientMock) Login(username, password string) (*scrape.LoginData, error) {
+func (m *ClientMock) Login(ctx context.Context, username, password string) (client.Client, *scrape.LoginData, error) {
 	args := m.Called(username, password)
 
-	return args.Get(0).(*scrape.LoginData), args.Error(1)
+	session, _ := args.Get(0).(client.Client)
+
+	return session, args.Get(1).(*scrape.LoginData), args.Error(2)
 }
 
-func (m *ClientMock) Tournaments(gender, league string, year int) ([]*volleynet.TournamentInfo, error) {
+func (m *ClientMock) Tournaments(ctx context.Context, gender, league string, year int) ([]*volleynet.TournamentInfo, error) {
 	args := m.Called(gender, league, year)
 
 	return args.Get(0).([]*volleynet.TournamentInfo), args.Error(1)
 }
 
-func (m *ClientMock) Ladder(gender string) ([]*volleynet.Player, error) {
+func (m *ClientMock) Ladder(ctx context.Context, gender string) ([]*volleynet.Player, error) {
 	args := m.Called(gender)
 
 	return args.Get(0).([]*volleynet.Player), args.Error(1)
 }
 
-func (m *ClientMock) ComplementTournament(tournament *volleynet.TournamentInfo) (*volleynet.Tournament, error) {
+func (m *ClientMock) PlayerProfile(ctx context.Context, playerID int) (*scrape.ProfileData, error) {
+	args := m.Called(playerID)
+
+	return args.Get(0).(*scrape.ProfileData), args.Error(1)
+}
+
+func (m *ClientMock) ComplementTournament(ctx context.Context, tournament *volleynet.TournamentInfo) (*volleynet.Tournament, error) {
 	args := m.Called(tournament)
 
 	return args.Get(0).(*volleynet.Tournament), args.Error(1)
 }
 
-func (m *ClientMock) WithdrawFromTournament(tournamentID int) error {
+func (m *ClientMock) Matches(ctx context.Context, tournament *volleynet.TournamentInfo) ([]*volleynet.Match, error) {
+	args := m.Called(tournament)
+
+	return args.Get(0).([]*volleynet.Match), args.Error(1)
+}
+
+func (m *ClientMock) WithdrawFromTournament(ctx context.Context, tournamentID int) error {
 	args := m.Called(tournamentID)
 
 	return args.Error(0)
 }
 
-func (m *ClientMock) EnterTournament(playerID, tournamentID int) error {
+func (m *ClientMock) EnterTournament(ctx context.Context, playerID, tournamentID int) error {
 	args := m.Called(playerID, tournamentID)
 
 	return args.Error(0)
 }
 
-func (m *ClientMock) SearchPlayers(firstName, lastName, birthday string) ([]*scrape.PlayerInfo, error) {
+func (m *ClientMock) SearchPlayers(ctx context.Context, firstName, lastName, birthday string) ([]*scrape.PlayerInfo, error) {
 	args := m.Called(firstName, lastName, birthday)
 
 	return args.Get(0).([]*scrape.PlayerInfo), args.Error(1)
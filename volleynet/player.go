@@ -22,3 +22,28 @@ type Player struct {
 	License      string     `json:"license"`
 	TotalPoints  int        `json:"totalPoints" db:"total_points"`
 }
+
+// PlayerClub is a club a player has played for in a season.
+type PlayerClub struct {
+	scores.Track
+
+	PlayerID int    `json:"playerId" db:"player_id"`
+	Season   string `json:"season"`
+	Club     string `json:"club"`
+}
+
+// YearOnlyBirthday returns a birthday of which only the year is known.
+// Since we only know the birth year a 'magic' time is added, so that
+// we can update it as soon as we have the exact date.
+func YearOnlyBirthday(year int) time.Time {
+	return time.Date(year, time.January, 1, 13, 37, 0, 0, time.UTC)
+}
+
+// IsYearOnlyBirthday returns true if the birthday has been created
+// by `YearOnlyBirthday`.
+func IsYearOnlyBirthday(birthday time.Time) bool {
+	return birthday.Month() == time.January &&
+		birthday.Day() == 1 &&
+		birthday.Hour() == 13 &&
+		birthday.Minute() == 37
+}
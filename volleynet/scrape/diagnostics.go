@@ -0,0 +1,199 @@
+package scrape
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Kinds of issues that can occur while parsing a page.
+const (
+	// IssueSkippedRow is recorded if a row could not be parsed and was skipped.
+	IssueSkippedRow = "skipped-row"
+	// IssueUnknownColumnCount is recorded if a row has an unexpected number of columns.
+	IssueUnknownColumnCount = "unknown-column-count"
+	// IssueMissingDetail is recorded if an expected detail key was not found.
+	IssueMissingDetail = "missing-detail"
+	// IssueMalformedPage is recorded if a page looks structurally wrong.
+	IssueMalformedPage = "malformed-page"
+	// IssueFailedRequest is recorded if a page could not be loaded.
+	IssueFailedRequest = "failed-request"
+)
+
+const defaultMaxIssues = 1000
+
+// Issue is a problem that occured while parsing a page.
+type Issue struct {
+	Time         time.Time `json:"time"`
+	Kind         string    `json:"kind"`
+	Page         string    `json:"page"`
+	TournamentID int       `json:"tournamentId,omitempty"`
+	Message      string    `json:"message"`
+	Snapshot     string    `json:"snapshot,omitempty"`
+}
+
+// Diagnostics collects the issues of all parsed pages, this allows us to
+// notice when volleynet.at changes its markup. If `SnapshotDir` is set the
+// raw html of pages that look structurally wrong is saved there.
+// A nil *Diagnostics is valid and discards all issues.
+type Diagnostics struct {
+	SnapshotDir string
+	MaxIssues   int
+
+	mutex  sync.Mutex
+	issues []Issue
+}
+
+// NewDiagnostics creates a new Diagnostics collector.
+func NewDiagnostics(snapshotDir string) *Diagnostics {
+	return &Diagnostics{
+		SnapshotDir: snapshotDir,
+		MaxIssues:   defaultMaxIssues,
+	}
+}
+
+// Record adds an issue, if `MaxIssues` is exceeded the oldest issue is dropped.
+func (d *Diagnostics) Record(issue Issue) {
+	if d == nil {
+		return
+	}
+
+	if issue.Time.IsZero() {
+		issue.Time = time.Now()
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.issues = append(d.issues, issue)
+
+	if d.MaxIssues > 0 && len(d.issues) > d.MaxIssues {
+		d.issues = d.issues[len(d.issues)-d.MaxIssues:]
+	}
+}
+
+// Issues returns all recorded issues.
+func (d *Diagnostics) Issues() []Issue {
+	if d == nil {
+		return []Issue{}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	issues := make([]Issue, len(d.issues))
+	copy(issues, d.issues)
+
+	return issues
+}
+
+func (d *Diagnostics) snapshot(page string, tournamentID int, raw []byte, now time.Time) (string, error) {
+	if d.SnapshotDir == "" {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(d.SnapshotDir, 0755); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%d-%s.html", page, tournamentID, now.Format("20060102-150405.000"))
+	path := filepath.Join(d.SnapshotDir, name)
+
+	return path, ioutil.WriteFile(path, raw, 0644)
+}
+
+// pageDiagnostics records the issues of a single parsed page.
+// A nil *pageDiagnostics is valid and discards all issues.
+type pageDiagnostics struct {
+	diagnostics  *Diagnostics
+	page         string
+	tournamentID int
+	raw          []byte
+	malformed    []string
+}
+
+// newPageDiagnostics starts recording the issues of a page, since the raw html
+// might have to be saved later the returned reader must be used for parsing.
+func newPageDiagnostics(d *Diagnostics, page string, tournamentID int, html io.Reader) (*pageDiagnostics, io.Reader) {
+	if d == nil {
+		return nil, html
+	}
+
+	raw, err := ioutil.ReadAll(html)
+
+	if err != nil {
+		raw = nil
+	}
+
+	return &pageDiagnostics{
+		diagnostics:  d,
+		page:         page,
+		tournamentID: tournamentID,
+		raw:          raw,
+	}, bytes.NewReader(raw)
+}
+
+func (p *pageDiagnostics) record(kind, format string, args ...interface{}) {
+	if p == nil {
+		return
+	}
+
+	p.diagnostics.Record(Issue{
+		Kind:         kind,
+		Page:         p.page,
+		TournamentID: p.tournamentID,
+		Message:      fmt.Sprintf(format, args...),
+	})
+}
+
+func (p *pageDiagnostics) skippedRow(format string, args ...interface{}) {
+	p.record(IssueSkippedRow, format, args...)
+}
+
+func (p *pageDiagnostics) unknownColumnCount(count int) {
+	p.record(IssueUnknownColumnCount, "unknown column count: %d", count)
+}
+
+func (p *pageDiagnostics) missingDetail(key string) {
+	p.record(IssueMissingDetail, "missing detail %q", key)
+}
+
+// malformedPage marks the page as structurally wrong, the issue is recorded
+// together with the page snapshot once `done` is called.
+func (p *pageDiagnostics) malformedPage(format string, args ...interface{}) {
+	if p == nil {
+		return
+	}
+
+	p.malformed = append(p.malformed, fmt.Sprintf(format, args...))
+}
+
+// done saves a snapshot of the page if it looks structurally wrong.
+func (p *pageDiagnostics) done() {
+	if p == nil || len(p.malformed) == 0 {
+		return
+	}
+
+	now := time.Now()
+	snapshot, err := p.diagnostics.snapshot(p.page, p.tournamentID, p.raw, now)
+
+	for _, message := range p.malformed {
+		if err != nil {
+			message = fmt.Sprintf("%s (saving snapshot failed: %v)", message, err)
+		}
+
+		p.diagnostics.Record(Issue{
+			Time:         now,
+			Kind:         IssueMalformedPage,
+			Page:         p.page,
+			TournamentID: p.tournamentID,
+			Message:      message,
+			Snapshot:     snapshot,
+		})
+	}
+}
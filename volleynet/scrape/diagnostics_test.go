@@ -0,0 +1,58 @@
+package scrape
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func TestDiagnosticsMalformedTournament(t *testing.T) {
+	diagnostics := NewDiagnostics(t.TempDir())
+	html := strings.NewReader("<html><body><h2>Wartungsarbeiten</h2></body></html>")
+
+	_, err := Tournament(html, time.Now(), &volleynet.TournamentInfo{ID: 1}, diagnostics)
+	test.Check(t, "Tournament() err: %v", err)
+
+	issues := diagnostics.Issues()
+
+	test.Assert(t, "Diagnostics.Issues() want len(issues) == 1, got: %d", len(issues) == 1, len(issues))
+	test.Equal(t, "Issue.Kind want: %s, got: %s", IssueMalformedPage, issues[0].Kind)
+	test.Equal(t, "Issue.TournamentID want: %d, got: %d", 1, issues[0].TournamentID)
+
+	_, err = os.Stat(issues[0].Snapshot)
+	test.Check(t, "snapshot was not saved: %v", err)
+}
+
+func TestDiagnosticsMissingTournamentDetails(t *testing.T) {
+	diagnostics := NewDiagnostics("")
+	html := strings.NewReader("<table><tbody><tr><td>Kategorie</td><td>ABV Tour AMATEUR 1</td></tr></tbody></table>")
+
+	_, err := Tournament(html, time.Now(), &volleynet.TournamentInfo{ID: 1}, diagnostics)
+	test.Check(t, "Tournament() err: %v", err)
+
+	issues := diagnostics.Issues()
+
+	test.Assert(t, "Diagnostics.Issues() want len(issues) == 3, got: %d", len(issues) == 3, len(issues))
+
+	for _, issue := range issues {
+		test.Equal(t, "Issue.Kind want: %s, got: %s", IssueMissingDetail, issue.Kind)
+	}
+}
+
+func TestDiagnosticsMaxIssues(t *testing.T) {
+	diagnostics := NewDiagnostics("")
+	diagnostics.MaxIssues = 2
+
+	diagnostics.Record(Issue{Message: "1"})
+	diagnostics.Record(Issue{Message: "2"})
+	diagnostics.Record(Issue{Message: "3"})
+
+	issues := diagnostics.Issues()
+
+	test.Assert(t, "Diagnostics.Issues() want len(issues) == 2, got: %d", len(issues) == 2, len(issues))
+	test.Equal(t, "Diagnostics.Issues() want oldest issue: %s, got: %s", "2", issues[0].Message)
+}
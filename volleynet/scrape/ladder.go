@@ -2,7 +2,7 @@ package scrape
 
 import (
 	"io"
-	"time"
+	"strconv"
 
 	"github.com/PuerkitoBio/goquery"
 
@@ -10,10 +10,15 @@ import (
 )
 
 // Ladder parses players from the ladder page.
-func Ladder(html io.Reader) ([]*volleynet.Player, error) {
+// Parse issues are recorded to `diagnostics` which may be nil.
+func Ladder(html io.Reader, diagnostics *Diagnostics) ([]*volleynet.Player, error) {
+	page, html := newPageDiagnostics(diagnostics, "ladder", 0, html)
+	defer page.done()
+
 	doc, err := parseHTML(html)
 
 	if err != nil {
+		page.malformedPage("invalid html: %v", err)
 		return nil, err
 	}
 
@@ -34,6 +39,7 @@ func Ladder(html io.Reader) ([]*volleynet.Player, error) {
 		player := parseLadderRow(row)
 
 		if player == nil {
+			page.unknownColumnCount(len(row.Find("td").Nodes))
 			continue
 		}
 
@@ -42,6 +48,10 @@ func Ladder(html io.Reader) ([]*volleynet.Player, error) {
 		players = append(players, player)
 	}
 
+	if len(players) == 0 {
+		page.malformedPage("no players found in %d rows", len(rows.Nodes))
+	}
+
 	return players, nil
 }
 
@@ -64,11 +74,8 @@ func parseLadderRow(row *goquery.Selection) *volleynet.Player {
 			p.FirstName, p.LastName = parsePlayerName(c)
 			p.ID, _ = parsePlayerIDFromSteckbrief(c.Find("a"))
 		case 3:
-			// since we only know the birth year add a 'magic' time, so that we
-			// can update it as soon as we have the information.
-			birthday, err := time.Parse("2006 15:04", trimmSelectionText(c)+" 13:37")
-
-			if err == nil {
+			if year, err := strconv.Atoi(trimmSelectionText(c)); err == nil {
+				birthday := volleynet.YearOnlyBirthday(year)
 				p.Birthday = &birthday
 			}
 		case 4:
@@ -64,7 +64,7 @@ func TestParseLadderTest(t *testing.T) {
 		},
 	}
 
-	ladder, err := Ladder(response)
+	ladder, err := Ladder(response, nil)
 
 	test.Check(t, "Ladder() err: %v", err)
 	test.Compare(t, "Ladder(): %s", ladder, expected)
@@ -13,7 +13,7 @@ var parseLoginDataMap = map[string]loginDataParser{
 		d.FirstName, d.LastName = parsePlayerName(value)
 	},
 	"Geburtsdatum": func(value *goquery.Selection, d *LoginData) {
-		// d.Birthday = value.Text() TODO
+		d.Birthday, _ = parseDate(value.Text())
 	},
 	"Lizenz": func(value *goquery.Selection, d *LoginData) {
 		d.License.Type = value.Text()
@@ -0,0 +1,153 @@
+package scrape
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+// Matches parses the matches of a tournament from the livescoring page.
+func Matches(html io.Reader, tournament *volleynet.TournamentInfo) ([]*volleynet.Match, error) {
+	doc, err := parseHTML(html)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parse matches")
+	}
+
+	matches := []*volleynet.Match{}
+	tables := doc.Find("tbody")
+
+	for i := range tables.Nodes {
+		rows := tables.Eq(i).Find("tr")
+
+		if trimmSelectionText(rows.First().Children().Eq(0)) != "Nr." {
+			continue
+		}
+
+		for j := range rows.Nodes {
+			if j == 0 {
+				continue
+			}
+
+			match, err := parseMatchRow(rows.Eq(j), tournament)
+
+			if err != nil {
+				// matches that haven't been drawn yet or byes
+				// can't be parsed, skip them
+				continue
+			}
+
+			matches = append(matches, match)
+		}
+	}
+
+	return matches, nil
+}
+
+func parseMatchRow(row *goquery.Selection, tournament *volleynet.TournamentInfo) (*volleynet.Match, error) {
+	columns := row.Find("td")
+
+	if len(columns.Nodes) != 6 {
+		return nil, fmt.Errorf("unknown match table column count: %d", len(columns.Nodes))
+	}
+
+	var err error
+	match := &volleynet.Match{TournamentID: tournament.ID}
+
+	for k := range columns.Nodes {
+		column := columns.Eq(k)
+
+		switch k {
+		case 0:
+			match.MatchNr, _ = findInt(column.Text())
+		case 1:
+			match.Round = trimmSelectionText(column)
+		case 2:
+			match.Court, _ = findInt(column.Text())
+		case 3:
+			match.Team1, err = parseMatchTeam(column, tournament.Gender)
+		case 4:
+			match.Team2, err = parseMatchTeam(column, tournament.Gender)
+		case 5:
+			err = parseMatchResult(trimmSelectionText(column), match)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return match, nil
+}
+
+func parseMatchTeam(column *goquery.Selection, gender string) (*volleynet.MatchTeam, error) {
+	links := column.Find("a")
+
+	if links.Length() != 2 {
+		return nil, fmt.Errorf("expected 2 players per team, got: %d", links.Length())
+	}
+
+	team := &volleynet.MatchTeam{}
+
+	for i := range links.Nodes {
+		link := links.Eq(i)
+		player := &volleynet.Player{Gender: gender}
+
+		var err error
+		player.ID, err = parsePlayerIDFromSteckbrief(link)
+
+		if err != nil {
+			return nil, err
+		}
+
+		player.FirstName, player.LastName = parsePlayerName(link)
+
+		if i == 0 {
+			team.Player1 = player
+		} else {
+			team.Player2 = player
+		}
+	}
+
+	return team, nil
+}
+
+var setResultRegex = regexp.MustCompile("(\\d+)\\s*:\\s*(\\d+)")
+
+// parseMatchResult parses set results in the form of "21:15, 18:21, 15:12"
+// and sets the winner of the match.
+func parseMatchResult(result string, match *volleynet.Match) error {
+	results := setResultRegex.FindAllStringSubmatch(result, -1)
+
+	if len(results) > 3 {
+		return fmt.Errorf("invalid match result: %q", result)
+	}
+
+	sets := []*volleynet.MatchSet{&match.Set1, &match.Set2, &match.Set3}
+	setsTeam1, setsTeam2 := 0, 0
+
+	for i, r := range results {
+		sets[i].Team1, _ = strconv.Atoi(r[1])
+		sets[i].Team2, _ = strconv.Atoi(r[2])
+
+		if sets[i].Team1 > sets[i].Team2 {
+			setsTeam1++
+		} else if sets[i].Team2 > sets[i].Team1 {
+			setsTeam2++
+		}
+	}
+
+	if setsTeam1 > setsTeam2 {
+		match.Winner = 1
+	} else if setsTeam2 > setsTeam1 {
+		match.Winner = 2
+	}
+
+	return nil
+}
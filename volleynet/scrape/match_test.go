@@ -0,0 +1,71 @@
+package scrape
+
+import (
+	"os"
+	"testing"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func matchPlayer(id int, firstName, lastName string) *volleynet.Player {
+	return &volleynet.Player{ID: id, FirstName: firstName, LastName: lastName, Gender: "M"}
+}
+
+func TestMatches(t *testing.T) {
+	response, _ := os.Open("../testdata/22764-livescoring.html")
+
+	bosseGruber := &volleynet.MatchTeam{
+		Player1: matchPlayer(22606, "Richard", "Bosse"),
+		Player2: matchPlayer(41275, "Raphael", "Gruber"),
+	}
+	jaegerMetzger := &volleynet.MatchTeam{
+		Player1: matchPlayer(28725, "Alexander", "Jäger"),
+		Player2: matchPlayer(20436, "Bernhard", "Metzger"),
+	}
+
+	expected := []*volleynet.Match{
+		{
+			TournamentID: 22764,
+			MatchNr:      1,
+			Round:        "Winner Runde 1",
+			Court:        1,
+			Team1:        bosseGruber,
+			Team2: &volleynet.MatchTeam{
+				Player1: matchPlayer(27201, "Alexander", "Jirgal"),
+				Player2: matchPlayer(17623, "Luca Maxim", "Wojnar"),
+			},
+			Set1:   volleynet.MatchSet{Team1: 21, Team2: 15},
+			Set2:   volleynet.MatchSet{Team1: 21, Team2: 17},
+			Winner: 1,
+		},
+		{
+			TournamentID: 22764,
+			MatchNr:      2,
+			Round:        "Winner Runde 1",
+			Court:        2,
+			Team1:        jaegerMetzger,
+			Team2: &volleynet.MatchTeam{
+				Player1: matchPlayer(10198, "Markus", "Mayer"),
+				Player2: matchPlayer(42403, "Constantin", "Schieber"),
+			},
+			Set1:   volleynet.MatchSet{Team1: 18, Team2: 21},
+			Set2:   volleynet.MatchSet{Team1: 21, Team2: 19},
+			Set3:   volleynet.MatchSet{Team1: 12, Team2: 15},
+			Winner: 2,
+		},
+		{
+			TournamentID: 22764,
+			MatchNr:      4,
+			Round:        "Winner Runde 2",
+			Court:        1,
+			Team1:        bosseGruber,
+			Team2:        jaegerMetzger,
+		},
+	}
+
+	matches, err := Matches(response, &volleynet.TournamentInfo{ID: 22764, Gender: "M"})
+
+	test.Check(t, "Matches() err: %v", err)
+	test.Compare(t, "Matches(): %s", matches, expected)
+}
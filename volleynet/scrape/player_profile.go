@@ -0,0 +1,98 @@
+package scrape
+
+import (
+	"io"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+// ProfileData contains the data of a player's profile (steckbrief) page.
+type ProfileData struct {
+	PlayerInfo
+
+	Club         string                  `json:"club"`
+	CountryUnion string                  `json:"countryUnion"`
+	License      License                 `json:"license"`
+	Clubs        []*volleynet.PlayerClub `json:"clubs"`
+}
+
+type profileDataParser func(*goquery.Selection, *ProfileData)
+
+var parseProfileDataMap = map[string]profileDataParser{
+	"Name": func(value *goquery.Selection, p *ProfileData) {
+		p.FirstName, p.LastName = parsePlayerName(value)
+	},
+	"Geburtsdatum": func(value *goquery.Selection, p *ProfileData) {
+		p.Birthday, _ = parseDate(value.Text())
+	},
+	"Landesverband": func(value *goquery.Selection, p *ProfileData) {
+		p.CountryUnion = trimmSelectionText(value)
+	},
+	"Verein": func(value *goquery.Selection, p *ProfileData) {
+		p.Club = trimmSelectionText(value)
+	},
+	"Lizenz": func(value *goquery.Selection, p *ProfileData) {
+		p.License.Type = trimmSelectionText(value)
+	},
+	"Lizenznummer": func(value *goquery.Selection, p *ProfileData) {
+		p.License.Nr = trimmSelectionText(value)
+	},
+}
+
+// PlayerProfile parses the profile (steckbrief) page of a player.
+func PlayerProfile(html io.Reader, playerID int) (*ProfileData, error) {
+	doc, err := parseHTML(html)
+
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &ProfileData{
+		PlayerInfo: PlayerInfo{ID: playerID},
+		Clubs:      []*volleynet.PlayerClub{},
+	}
+
+	tables := doc.Find("tbody")
+
+	for i := range tables.Nodes {
+		rows := tables.Eq(i).Find("tr")
+
+		if trimmSelectionText(rows.First().Children().Eq(0)) == "Saison" {
+			parseClubHistory(rows, profile)
+			continue
+		}
+
+		for j := range rows.Nodes {
+			row := rows.Eq(j).Children()
+			columnName := trimmSelectionText(row.Eq(0))
+
+			if parser, ok := parseProfileDataMap[columnName]; ok {
+				parser(row.Eq(1), profile)
+			}
+		}
+	}
+
+	return profile, nil
+}
+
+func parseClubHistory(rows *goquery.Selection, profile *ProfileData) {
+	for j := range rows.Nodes {
+		if j == 0 {
+			continue
+		}
+
+		columns := rows.Eq(j).Find("td")
+
+		if len(columns.Nodes) != 2 {
+			continue
+		}
+
+		profile.Clubs = append(profile.Clubs, &volleynet.PlayerClub{
+			PlayerID: profile.ID,
+			Season:   trimmSelectionText(columns.Eq(0)),
+			Club:     trimmSelectionText(columns.Eq(1)),
+		})
+	}
+}
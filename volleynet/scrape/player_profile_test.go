@@ -0,0 +1,38 @@
+package scrape
+
+import (
+	"os"
+	"testing"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func TestPlayerProfile(t *testing.T) {
+	response, _ := os.Open("../testdata/steckbrief-22606.html")
+
+	expected := &ProfileData{
+		PlayerInfo: PlayerInfo{
+			ID:        22606,
+			FirstName: "Richard",
+			LastName:  "Bosse",
+			Birthday:  test.MustParseDate("17.05.1991"),
+		},
+		Club:         "1. Stockerauer Beachvolleyballverein",
+		CountryUnion: "NÖVV",
+		License: License{
+			Nr:   "22606/2018",
+			Type: "A",
+		},
+		Clubs: []*volleynet.PlayerClub{
+			{PlayerID: 22606, Season: "2018", Club: "1. Stockerauer Beachvolleyballverein"},
+			{PlayerID: 22606, Season: "2017", Club: "1. Stockerauer Beachvolleyballverein"},
+			{PlayerID: 22606, Season: "2016", Club: "Beachvolleyball Club Wien"},
+		},
+	}
+
+	profile, err := PlayerProfile(response, 22606)
+
+	test.Check(t, "PlayerProfile() err: %v", err)
+	test.Compare(t, "PlayerProfile(): %s", profile, expected)
+}
@@ -3,6 +3,7 @@ package scrape
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -13,14 +14,20 @@ import (
 )
 
 // Tournament adds remaining details to the tournament (parsed by TournamentList()).
+// Parse issues are recorded to `diagnostics` which may be nil.
 func Tournament(
 	html io.Reader,
 	now time.Time,
-	tournament *volleynet.TournamentInfo) (*volleynet.Tournament, error) {
+	tournament *volleynet.TournamentInfo,
+	diagnostics *Diagnostics) (*volleynet.Tournament, error) {
+
+	page, html := newPageDiagnostics(diagnostics, "tournament", tournament.ID, html)
+	defer page.done()
 
 	doc, err := parseHTML(html)
 
 	if err != nil {
+		page.malformedPage("invalid html: %v", err)
 		return nil, errors.Wrap(err, "parse tournament")
 	}
 
@@ -28,11 +35,13 @@ func Tournament(
 
 	parseTournamentNotes(doc, t)
 
-	if err = parseTournamentDetails(doc, t); err != nil {
+	if err = parseTournamentDetails(doc, t, page); err != nil {
+		page.malformedPage("parsing details failed: %v", err)
 		return nil, errors.Wrap(err, "parse tournament details")
 	}
 
-	if err = parseFullTournamentTeams(doc, t); err != nil {
+	if err = parseFullTournamentTeams(doc, t, page); err != nil {
+		page.malformedPage("parsing teams failed: %v", err)
 		return nil, errors.Wrap(err, "parse tournament teams")
 	}
 
@@ -159,8 +168,12 @@ var parseTournamentDetailsMap = map[string]detailsParser{
 	},
 }
 
-func parseTournamentDetails(doc *goquery.Document, t *volleynet.Tournament) error {
+// requiredTournamentDetails are the details every tournament page must contain.
+var requiredTournamentDetails = []string{"Kategorie", "Modus", "Datum", "Ort"}
+
+func parseTournamentDetails(doc *goquery.Document, t *volleynet.Tournament, page *pageDiagnostics) error {
 	table := doc.Find("tbody")
+	found := map[string]bool{}
 
 	for i := range table.Nodes {
 		r := table.Eq(i)
@@ -175,6 +188,8 @@ func parseTournamentDetails(doc *goquery.Document, t *volleynet.Tournament) erro
 				value := row.Eq(1)
 
 				if parser, ok := parseTournamentDetailsMap[columnName]; ok {
+					found[columnName] = true
+
 					if err := parser(value, t); err != nil {
 						return errors.Wrapf(err, "error parsing column %s with value %+v", value.Text(), t)
 					}
@@ -183,10 +198,21 @@ func parseTournamentDetails(doc *goquery.Document, t *volleynet.Tournament) erro
 		}
 	}
 
+	if len(found) == 0 {
+		page.malformedPage("no tournament details found")
+		return nil
+	}
+
+	for _, key := range requiredTournamentDetails {
+		if !found[key] {
+			page.missingDetail(key)
+		}
+	}
+
 	return nil
 }
 
-func parseFullTournamentTeams(doc *goquery.Document, t *volleynet.Tournament) error {
+func parseFullTournamentTeams(doc *goquery.Document, t *volleynet.Tournament, page *pageDiagnostics) error {
 	tables := doc.Find("tbody")
 	t.Teams = []*volleynet.TournamentTeam{}
 
@@ -205,6 +231,12 @@ func parseFullTournamentTeams(doc *goquery.Document, t *volleynet.Tournament) er
 
 				player, err := parsePlayerRow(rows.Eq(j), team)
 
+				if count, ok := err.(unknownColumnCountError); ok {
+					page.unknownColumnCount(int(count))
+				} else if err != nil {
+					page.skippedRow("skipped player row %d: %v", j, err)
+				}
+
 				if err != nil {
 					j++ // if it's not possible to parse a player, skip the entire team
 					continue
@@ -258,6 +290,63 @@ func Entry(body io.Reader) (EntryResult, error) {
 	return result, nil
 }
 
+// WithdrawalResult contains the data that is returned by the
+// TournamentWithdrawal endpoint.
+type WithdrawalResult struct {
+	Successfull bool `json:"successfull"`
+}
+
+// ErrLoginRequired is returned if volleynet.at answers with its login form,
+// e.g. because the session of the client has expired.
+var ErrLoginRequired = errors.New("volleynet requires a login")
+
+// maxUnverifiedExcerpt limits the text of an unverified page in its issue.
+const maxUnverifiedExcerpt = 500
+
+// Withdrawal parses the result of a tournament withdrawal. Only the login
+// form is known to mean that the withdrawal failed. The confirmation that
+// is looked for has not been checked against a captured volleynet.at
+// response yet, so a page without it counts as success as well, its text
+// is recorded in `diagnostics` together with a snapshot of the page.
+func Withdrawal(body io.Reader, tournamentID int, diagnostics *Diagnostics) (WithdrawalResult, error) {
+	page, body := newPageDiagnostics(diagnostics, "withdrawal", tournamentID, body)
+	defer page.done()
+
+	doc, err := parseHTML(body)
+	result := WithdrawalResult{}
+
+	if err != nil {
+		page.malformedPage("invalid html: %v", err)
+		return result, errors.Wrap(err, "could not parse html")
+	}
+
+	if doc.Find("[name='login_name']").Length() > 0 {
+		return result, ErrLoginRequired
+	}
+
+	if doc.Find("[name='XX_unique_write_XXBeach/Profile/TurnierAbmeldungErfolgreich']").Length() == 0 {
+		text := strings.Join(strings.Fields(doc.Find("body").Text()), " ")
+
+		if runes := []rune(text); len(runes) > maxUnverifiedExcerpt {
+			text = string(runes[:maxUnverifiedExcerpt])
+		}
+
+		page.malformedPage("unverified withdrawal page, assuming success: %q", text)
+	}
+
+	result.Successfull = true
+
+	return result, nil
+}
+
+// unknownColumnCountError is returned if a tournament player
+// table row has an unknown column count.
+type unknownColumnCountError int
+
+func (e unknownColumnCountError) Error() string {
+	return fmt.Sprintf("unknown tournament player table row count: %d", int(e))
+}
+
 func parsePlayerRow(row *goquery.Selection, team *volleynet.TournamentTeam) (player *volleynet.Player, err error) {
 	player = &volleynet.Player{}
 
@@ -323,7 +412,7 @@ func parsePlayerRow(row *goquery.Selection, team *volleynet.TournamentTeam) (pla
 				player.CountryUnion = trimmSelectionText(column)
 			}
 		} else {
-			return nil, fmt.Errorf("unknown tournament player table row count: %d", columnsCount)
+			return nil, unknownColumnCountError(columnsCount)
 		}
 
 		if err != nil {
@@ -13,10 +13,15 @@ import (
 )
 
 // TournamentList parses the list of tournaments.
-func TournamentList(html io.Reader, host string) ([]*volleynet.TournamentInfo, error) {
+// Parse issues are recorded to `diagnostics` which may be nil.
+func TournamentList(html io.Reader, host string, diagnostics *Diagnostics) ([]*volleynet.TournamentInfo, error) {
+	page, html := newPageDiagnostics(diagnostics, "tournament-list", 0, html)
+	defer page.done()
+
 	doc, err := parseHTML(html)
 
 	if err != nil {
+		page.malformedPage("invalid html: %v", err)
 		return nil, err
 	}
 
@@ -30,12 +35,18 @@ func TournamentList(html io.Reader, host string) ([]*volleynet.TournamentInfo, e
 		columns := r.Find("td")
 
 		if len(columns.Nodes) != 5 {
+			page.unknownColumnCount(len(columns.Nodes))
 			continue
 		}
 
 		column := columns.Eq(2)
 
 		tournament := extractTournamentLinkData(parseHref(column.Find("a")), host)
+
+		if tournament == nil {
+			page.skippedRow("skipped row %d: tournament link not found", i)
+			continue
+		}
 		tournament.Name = trimmTournamentName(column)
 
 		column = columns.Eq(1)
@@ -61,6 +72,10 @@ func TournamentList(html io.Reader, host string) ([]*volleynet.TournamentInfo, e
 		tournaments = append(tournaments, tournament)
 	}
 
+	if len(tournaments) == 0 && len(rows.Nodes) > 0 {
+		page.malformedPage("no tournaments found in %d rows", len(rows.Nodes))
+	}
+
 	return tournaments, nil
 }
 
@@ -11,7 +11,7 @@ import (
 func TestTournamentList(t *testing.T) {
 	response, _ := os.Open("../testdata/tournament-list-amateur.html")
 
-	tournaments, err := TournamentList(response, "http://example.com")
+	tournaments, err := TournamentList(response, "http://example.com", nil)
 
 	test.Check(t, "Tournaments() err: %s", err)
 	test.Compare(t, "TournamentList() err: mismatch of tournament list\n%s", tournaments, tournamentListAmateur)
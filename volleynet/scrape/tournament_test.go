@@ -2,6 +2,7 @@ package scrape
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -549,9 +550,42 @@ func TestEntry(t *testing.T) {
 	test.Assert(t, "Entry().Successfull should be true", result.Successfull)
 }
 
+func TestWithdrawal(t *testing.T) {
+	reader, _ := os.Open("../testdata/withdrawal.html")
+
+	result, err := Withdrawal(reader, 22764, nil)
+
+	test.Check(t, "Withdrawal() err: %s", err)
+	test.Assert(t, "Withdrawal().Successfull should be true", result.Successfull)
+}
+
+func TestWithdrawalUnverifiedPage(t *testing.T) {
+	reader := strings.NewReader(`<html><body><h2>Abmeldung</h2><p>Die Abmeldung wurde gespeichert.</p></body></html>`)
+	diagnostics := NewDiagnostics(t.TempDir())
+
+	result, err := Withdrawal(reader, 22764, diagnostics)
+
+	test.Check(t, "Withdrawal() err: %s", err)
+	test.Assert(t, "Withdrawal().Successfull should be true", result.Successfull)
+
+	issues := diagnostics.Issues()
+	test.Assert(t, "Withdrawal() want a snapshot of the page, got: %+v", len(issues) == 1 && issues[0].Snapshot != "", issues)
+	test.Assert(t, "Withdrawal() want the text of the page in the issue, got: %q",
+		strings.Contains(issues[0].Message, "Die Abmeldung wurde gespeichert."), issues[0].Message)
+}
+
+func TestWithdrawalLoginRequired(t *testing.T) {
+	reader := strings.NewReader(`<html><body><form><input name="login_name" /><input name="login_pass" type="password" /></form></body></html>`)
+
+	result, err := Withdrawal(reader, 22764, nil)
+
+	test.Assert(t, "Withdrawal() want ErrLoginRequired, got: %v", err == ErrLoginRequired, err)
+	test.Assert(t, "Withdrawal().Successfull should be false", !result.Successfull)
+}
+
 // func TestSpecificTournament(t *testing.T) {
 // 	reader, _ := os.Open("../testdata/23775-done.html")
-// 	tournament, _ := Tournament(reader, time.Now(), &volleynet.TournamentInfo{})
+// 	tournament, _ := Tournament(reader, time.Now(), &volleynet.TournamentInfo{}, nil)
 
 // 	fmt.Print(tournament)
 // }
@@ -561,7 +595,7 @@ func TestTournament(t *testing.T) {
 		t.Run(tt.file, func(t *testing.T) {
 			response, _ := os.Open(tt.file)
 
-			tournament, err := Tournament(response, tt.now, &tt.tournament)
+			tournament, err := Tournament(response, tt.now, &tt.tournament, nil)
 
 			if err != nil {
 				t.Fatalf("Tournament() err: %s", err)
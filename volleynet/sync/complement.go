@@ -0,0 +1,89 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+// TournamentErrors contains the errors of all tournaments
+// that could not be loaded, mapped by the tournament id.
+type TournamentErrors map[int]error
+
+func (e TournamentErrors) Error() string {
+	return fmt.Sprintf("loading %d tournament(s) failed: %s", len(e), errorList("tournament", e))
+}
+
+// errorList lists the errors ordered by id, e.g. "tournament 1: timeout; tournament 2: ...".
+func errorList(name string, errs map[int]error) string {
+	ids := make([]int, 0, len(errs))
+
+	for id := range errs {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	messages := make([]string, len(ids))
+
+	for i, id := range ids {
+		messages[i] = fmt.Sprintf("%s %d: %v", name, id, errs[id])
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// mergeTournamentErrors combines the `TournamentErrors` of `errs`,
+// it returns nil if there are none.
+func mergeTournamentErrors(errs ...error) error {
+	merged := TournamentErrors{}
+
+	for _, err := range errs {
+		tournamentErrors, _ := err.(TournamentErrors)
+
+		for id, err := range tournamentErrors {
+			if _, ok := merged[id]; !ok {
+				merged[id] = err
+			}
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil
+	}
+
+	return merged
+}
+
+// complementTournaments loads the details of all `tournaments` with a pool of
+// `s.Workers` workers. Tournaments that could not be loaded are left out of
+// the result and their errors are returned as `TournamentErrors`.
+func (s *Service) complementTournaments(ctx context.Context, tournaments []*volleynet.TournamentInfo) (
+	[]*volleynet.Tournament, error) {
+	complemented := make([]*volleynet.Tournament, len(tournaments))
+	errs := make([]error, len(tournaments))
+
+	s.parallel(len(tournaments), func(i int) {
+		complemented[i], errs[i] = s.Client.ComplementTournament(ctx, tournaments[i])
+	})
+
+	current := []*volleynet.Tournament{}
+	tournamentErrors := TournamentErrors{}
+
+	for i, t := range complemented {
+		if errs[i] != nil {
+			tournamentErrors[tournaments[i].ID] = errs[i]
+		} else {
+			current = append(current, t)
+		}
+	}
+
+	if len(tournamentErrors) > 0 {
+		return current, tournamentErrors
+	}
+
+	return current, nil
+}
@@ -0,0 +1,253 @@
+package sync
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+// actions of an `EntityDiff`.
+const (
+	DiffNew    = "new"
+	DiffUpdate = "update"
+	DiffDelete = "delete"
+)
+
+// Diff lists the changes a sync would persist.
+type Diff struct {
+	Tournaments []EntityDiff `json:"tournaments"`
+	Teams       []EntityDiff `json:"teams"`
+	Players     []EntityDiff `json:"players"`
+}
+
+// EntityDiff is the change of a single entity, `Fields` is only
+// set for updates.
+type EntityDiff struct {
+	Key    string        `json:"key"`
+	Action string        `json:"action"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// FieldChange is the change of a single field of an entity.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// NewDiff returns an empty diff.
+func NewDiff() *Diff {
+	return &Diff{
+		Tournaments: []EntityDiff{},
+		Teams:       []EntityDiff{},
+		Players:     []EntityDiff{},
+	}
+}
+
+// IsEmpty returns true if the sync would not change anything.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Tournaments) == 0 && len(d.Teams) == 0 && len(d.Players) == 0
+}
+
+// Merge adds the changes of `other` to the diff.
+func (d *Diff) Merge(other *Diff) {
+	d.Tournaments = append(d.Tournaments, other.Tournaments...)
+	d.Teams = append(d.Teams, other.Teams...)
+	d.Players = append(d.Players, other.Players...)
+}
+
+func (d *Diff) sort() {
+	for _, entities := range [][]EntityDiff{d.Tournaments, d.Teams, d.Players} {
+		sort.SliceStable(entities, func(i, j int) bool {
+			return entities[i].Key < entities[j].Key
+		})
+	}
+}
+
+// addTournaments adds the tournament `changes`, the persisted version
+// of updated tournaments is loaded from the repository.
+func (s *Service) addTournaments(d *Diff, changes *TournamentChanges) error {
+	for _, t := range changes.New {
+		d.Tournaments = append(d.Tournaments, EntityDiff{Key: tournamentKey(t), Action: DiffNew})
+	}
+
+	for _, t := range changes.Update {
+		persisted, err := s.TournamentRepo.Get(t.ID)
+
+		if err != nil {
+			return errors.Wrap(err, "loading the persisted tournament failed")
+		}
+
+		d.Tournaments = append(d.Tournaments, EntityDiff{
+			Key:    tournamentKey(t),
+			Action: DiffUpdate,
+			Fields: diffFields(persisted, t),
+		})
+	}
+
+	for _, t := range changes.Delete {
+		d.Tournaments = append(d.Tournaments, EntityDiff{Key: tournamentKey(t), Action: DiffDelete})
+	}
+
+	return nil
+}
+
+// addTeams adds the team `changes`, the persisted version
+// of updated teams is loaded from the repository.
+func (s *Service) addTeams(d *Diff, changes *TeamChanges) error {
+	for _, t := range changes.New {
+		d.Teams = append(d.Teams, EntityDiff{Key: artificialTeamKey(t), Action: DiffNew})
+	}
+
+	persistedTeams := map[int]map[string]*volleynet.TournamentTeam{}
+
+	for _, t := range changes.Update {
+		teams, ok := persistedTeams[t.TournamentID]
+
+		if !ok {
+			persisted, err := s.TeamRepo.ByTournament(t.TournamentID)
+
+			if err != nil {
+				return errors.Wrap(err, "loading the persisted tournament teams failed")
+			}
+
+			teams = createTeamMap(persisted)
+			persistedTeams[t.TournamentID] = teams
+		}
+
+		key := artificialTeamKey(t)
+
+		d.Teams = append(d.Teams, EntityDiff{
+			Key:    key,
+			Action: DiffUpdate,
+			Fields: diffFields(teams[key], t),
+		})
+	}
+
+	for _, t := range changes.Delete {
+		d.Teams = append(d.Teams, EntityDiff{Key: artificialTeamKey(t), Action: DiffDelete})
+	}
+
+	return nil
+}
+
+// addNewPlayers adds the players of `teams` that are not persisted yet.
+func (s *Service) addNewPlayers(d *Diff, teams []*volleynet.TournamentTeam) error {
+	for _, p := range distinctPlayers(teams) {
+		_, err := s.PlayerRepo.Get(p.ID)
+
+		if errors.Cause(err) == scores.ErrNotFound {
+			d.Players = append(d.Players, EntityDiff{Key: playerKey(p), Action: DiffNew})
+		} else if err != nil {
+			return errors.Wrap(err, "loading the persisted player failed")
+		}
+	}
+
+	return nil
+}
+
+func (d *Diff) addPlayer(info PlayerSyncInformation, merged *volleynet.Player) {
+	if info.IsNew {
+		d.Players = append(d.Players, EntityDiff{Key: playerKey(info.NewPlayer), Action: DiffNew})
+		return
+	}
+
+	fields := diffFields(info.OldPlayer, merged)
+
+	if len(fields) == 0 {
+		return
+	}
+
+	d.Players = append(d.Players, EntityDiff{
+		Key:    playerKey(merged),
+		Action: DiffUpdate,
+		Fields: fields,
+	})
+}
+
+func tournamentKey(t *volleynet.Tournament) string {
+	return fmt.Sprintf("%d", t.ID)
+}
+
+func playerKey(p *volleynet.Player) string {
+	return fmt.Sprintf("%d", p.ID)
+}
+
+var (
+	trackType = reflect.TypeOf(scores.Track{})
+	timeType  = reflect.TypeOf(time.Time{})
+)
+
+// diffFields compares the exported fields of two structs of the same type,
+// the fields of embedded structs are compared as if they were part of the
+// struct. Slices are skipped and structs that are referenced by a pointer,
+// e.g. the players of a team, are only compared by their `ID`.
+func diffFields(old, new interface{}) []FieldChange {
+	changes := []FieldChange{}
+
+	diffStruct(&changes, reflect.Indirect(reflect.ValueOf(old)), reflect.Indirect(reflect.ValueOf(new)))
+
+	return changes
+}
+
+func diffStruct(changes *[]FieldChange, old, new reflect.Value) {
+	if !old.IsValid() || !new.IsValid() {
+		return
+	}
+
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+
+		if field.PkgPath != "" || field.Type == trackType || field.Type.Kind() == reflect.Slice {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			diffStruct(changes, old.Field(i), new.Field(i))
+			continue
+		}
+
+		oldValue, newValue := comparable(old.Field(i)), comparable(new.Field(i))
+
+		if !equal(oldValue, newValue) {
+			*changes = append(*changes, FieldChange{Field: field.Name, Old: oldValue, New: newValue})
+		}
+	}
+}
+
+// comparable dereferences pointers and replaces structs
+// that are not times with their `ID`.
+func comparable(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Struct && v.Type() != timeType {
+		if id := v.FieldByName("ID"); id.IsValid() {
+			return id.Interface()
+		}
+	}
+
+	return v.Interface()
+}
+
+func equal(old, new interface{}) bool {
+	oldTime, ok := old.(time.Time)
+	newTime, ok2 := new.(time.Time)
+
+	if ok && ok2 {
+		return oldTime.Equal(newTime)
+	}
+
+	return reflect.DeepEqual(old, new)
+}
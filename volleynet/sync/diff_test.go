@@ -0,0 +1,49 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raphi011/scores-api/test"
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func TestDiffFieldsOfTeams(t *testing.T) {
+	old := &volleynet.TournamentTeam{
+		TournamentID: 1,
+		Player1:      &volleynet.Player{ID: 1, FirstName: "Max"},
+		Player2:      &volleynet.Player{ID: 2},
+		Seed:         3,
+	}
+
+	new := *old
+	new.Player1 = &volleynet.Player{ID: 1, FirstName: "Maximilian"}
+	new.Seed = 1
+	new.Deregistered = true
+
+	test.Compare(t, "diffFields() diff: %s", []FieldChange{
+		{Field: "Deregistered", Old: false, New: true},
+		{Field: "Seed", Old: 3, New: 1},
+	}, diffFields(old, &new))
+}
+
+func TestDiffFieldsOfTournaments(t *testing.T) {
+	start := time.Now()
+	endRegistration := start.Add(-24 * time.Hour)
+
+	old := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{ID: 1, Start: start, Name: "Wien"},
+		Teams:          []*volleynet.TournamentTeam{},
+	}
+
+	new := *old
+	new.Start = start.In(time.UTC)
+	new.Status = volleynet.StatusDone
+	new.EndRegistration = &endRegistration
+	new.Teams = nil
+
+	test.Compare(t, "diffFields() diff: %s", []FieldChange{
+		{Field: "Status", Old: "", New: volleynet.StatusDone},
+		{Field: "EndRegistration", Old: nil, New: endRegistration},
+	}, diffFields(old, &new))
+}
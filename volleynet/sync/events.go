@@ -4,7 +4,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
 	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/volleynet"
 )
 
 const (
@@ -55,3 +58,171 @@ func (s *Service) publishEndScrapeEvent(report *Changes, end time.Time) {
 		})
 	}
 }
+
+// names of the fine-grained events that are published after
+// the changes of a sync have been persisted.
+const (
+	// EventsType matches all events that are published by the sync.
+	EventsType = "volleynet/*"
+
+	// TournamentEventsType matches all tournament events.
+	TournamentEventsType = "volleynet/tournament/*"
+	// TournamentCreatedEventType is published for new tournaments.
+	TournamentCreatedEventType = "volleynet/tournament/created"
+	// RegistrationOpenedEventType is published when the registration of a tournament opens.
+	RegistrationOpenedEventType = "volleynet/tournament/registration-opened"
+	// RegistrationClosedEventType is published when the registration of a tournament closes.
+	RegistrationClosedEventType = "volleynet/tournament/registration-closed"
+	// TournamentCanceledEventType is published when a tournament is canceled.
+	TournamentCanceledEventType = "volleynet/tournament/canceled"
+	// ResultsPostedEventType is published when the results of a tournament are posted.
+	ResultsPostedEventType = "volleynet/tournament/results-posted"
+
+	// TeamEventsType matches all team events.
+	TeamEventsType = "volleynet/team/*"
+	// TeamSignedUpEventType is published when a team signs up for an existing tournament.
+	TeamSignedUpEventType = "volleynet/team/signed-up"
+	// TeamDeregisteredEventType is published when a team is removed from a tournament.
+	TeamDeregisteredEventType = "volleynet/team/deregistered"
+
+	// LadderEventsType matches all ladder events.
+	LadderEventsType = "volleynet/ladder/*"
+	// RankChangedEventType is published when the ladder rank of a player changes.
+	RankChangedEventType = "volleynet/ladder/rank-changed"
+)
+
+// TournamentEvent is the body of the tournament events.
+type TournamentEvent struct {
+	ID         string                   `json:"id"`
+	Timestamp  time.Time                `json:"time"`
+	RunID      int                      `json:"runId"`
+	Tournament volleynet.TournamentInfo `json:"tournament"`
+}
+
+// TeamEvent is the body of the team events.
+type TeamEvent struct {
+	ID           string                    `json:"id"`
+	Timestamp    time.Time                 `json:"time"`
+	RunID        int                       `json:"runId"`
+	TournamentID int                       `json:"tournamentId"`
+	Gender       string                    `json:"gender"` // the gender of the tournament
+	Team         *volleynet.TournamentTeam `json:"team"`
+}
+
+// RankChangedEvent is the body of the `RankChangedEventType` event.
+type RankChangedEvent struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"time"`
+	RunID     int       `json:"runId"`
+	PlayerID  int       `json:"playerId"`
+	Gender    string    `json:"gender"`
+	OldRank   int       `json:"oldRank"`
+	NewRank   int       `json:"newRank"`
+}
+
+func (s *Service) publish(evts ...events.Event) {
+	if s.Subscriptions == nil {
+		return
+	}
+
+	for _, event := range evts {
+		s.Subscriptions.Publish(event)
+	}
+}
+
+// tournamentEvents returns the events of the changes of a single tournament,
+// it must be called before the changes are persisted since it loads the
+// previous state of updated tournaments from the repository.
+func (s *Service) tournamentEvents(report *tournamentChanges) ([]events.Event, error) {
+	now := time.Now()
+	evts := []events.Event{}
+
+	tournamentEvent := func(name string, t *volleynet.Tournament) events.Event {
+		return events.Event{
+			Name: name,
+			Body: TournamentEvent{
+				ID:         uuid.New().String(),
+				Timestamp:  now,
+				RunID:      report.RunID,
+				Tournament: t.TournamentInfo,
+			},
+		}
+	}
+
+	teamEvent := func(name, gender string, t *volleynet.TournamentTeam) events.Event {
+		return events.Event{
+			Name: name,
+			Body: TeamEvent{
+				ID:           uuid.New().String(),
+				Timestamp:    now,
+				RunID:        report.RunID,
+				TournamentID: t.TournamentID,
+				Gender:       gender,
+				Team:         t,
+			},
+		}
+	}
+
+	for _, t := range report.TournamentInfo.New {
+		evts = append(evts, tournamentEvent(TournamentCreatedEventType, t))
+	}
+
+	for _, t := range report.TournamentInfo.Update {
+		persisted, err := s.TournamentRepo.Get(t.ID)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "loading the persisted tournament failed")
+		}
+
+		if !persisted.RegistrationOpen && t.RegistrationOpen {
+			evts = append(evts, tournamentEvent(RegistrationOpenedEventType, t))
+		} else if persisted.RegistrationOpen && !t.RegistrationOpen {
+			evts = append(evts, tournamentEvent(RegistrationClosedEventType, t))
+		}
+
+		if persisted.Status != t.Status {
+			switch t.Status {
+			case volleynet.StatusCanceled:
+				evts = append(evts, tournamentEvent(TournamentCanceledEventType, t))
+			case volleynet.StatusDone:
+				evts = append(evts, tournamentEvent(ResultsPostedEventType, t))
+			}
+		}
+	}
+
+	// the teams of a new tournament are part of its creation
+	if len(report.TournamentInfo.New) > 0 || (len(report.Team.New) == 0 && len(report.Team.Delete) == 0) {
+		return evts, nil
+	}
+
+	tournament, err := s.TournamentRepo.Get(report.tournamentID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "loading the persisted tournament failed")
+	}
+
+	for _, t := range report.Team.New {
+		evts = append(evts, teamEvent(TeamSignedUpEventType, tournament.Gender, t))
+	}
+
+	for _, t := range report.Team.Delete {
+		evts = append(evts, teamEvent(TeamDeregisteredEventType, tournament.Gender, t))
+	}
+
+	return evts, nil
+}
+
+func rankChangedEvent(runID int, old, new *volleynet.Player) events.Event {
+	return events.Event{
+		Name: RankChangedEventType,
+		Body: RankChangedEvent{
+			ID:        uuid.New().String(),
+			Timestamp: time.Now(),
+			RunID:     runID,
+			PlayerID:  new.ID,
+			Gender:    new.Gender,
+			OldRank:   old.LadderRank,
+			NewRank:   new.LadderRank,
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package sync
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+// recordChanges persists the field changes of the updated tournaments and
+// teams, it must be called before the updates are persisted since it
+// loads the previous values from the repositories.
+func (s *Service) recordChanges(report *tournamentChanges) error {
+	if s.ChangeRepo == nil {
+		return nil
+	}
+
+	now := time.Now()
+	changes := []*volleynet.TournamentChange{}
+
+	newChange := func(tournamentID int, team string, field FieldChange) *volleynet.TournamentChange {
+		return &volleynet.TournamentChange{
+			TournamentID: tournamentID,
+			Team:         team,
+			Field:        field.Field,
+			OldValue:     formatValue(field.Old),
+			NewValue:     formatValue(field.New),
+			SyncRunID:    report.RunID,
+			ChangedAt:    now,
+		}
+	}
+
+	for _, t := range report.TournamentInfo.Update {
+		persisted, err := s.TournamentRepo.Get(t.ID)
+
+		if err != nil {
+			return errors.Wrap(err, "loading the persisted tournament failed")
+		}
+
+		for _, field := range diffFields(persisted, t) {
+			changes = append(changes, newChange(t.ID, "", field))
+		}
+	}
+
+	if len(report.Team.Update) > 0 {
+		persisted, err := s.TeamRepo.ByTournament(report.tournamentID)
+
+		if err != nil {
+			return errors.Wrap(err, "loading the persisted tournament teams failed")
+		}
+
+		persistedTeams := createTeamMap(persisted)
+
+		for _, t := range report.Team.Update {
+			key := artificialTeamKey(t)
+
+			for _, field := range diffFields(persistedTeams[key], t) {
+				changes = append(changes, newChange(t.TournamentID, key, field))
+			}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(s.ChangeRepo.NewBatch(changes...), "persisting the tournament changes failed")
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,6 +1,9 @@
 package sync
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/pkg/errors"
 	"github.com/raphi011/scores-api/volleynet"
 )
@@ -12,23 +15,71 @@ type LadderSyncReport struct {
 }
 
 // Ladder synchronizes player and rank data of all players of a certain `gender`
-func (s *Service) Ladder(gender string) (*LadderSyncReport, error) {
-	ranks, err := s.Client.Ladder(gender)
+func (s *Service) Ladder(ctx context.Context, gender string) (*LadderSyncReport, error) {
+	run := newRun("ladder", fmt.Sprintf("gender=%s", gender))
 	report := &LadderSyncReport{}
 
+	if err := s.startRun(run); err != nil {
+		return nil, err
+	}
+
+	err := s.ladder(ctx, report, nil, run.ID, gender)
+
+	run.NewPlayers = report.NewPlayers
+	run.UpdatedPlayers = report.UpdatedPlayers
+
+	if err = s.finishRun(run, report.NewPlayers+report.UpdatedPlayers > 0, err); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// LadderDryRun runs the same sync as `Ladder` but instead of persisting
+// the changes it returns the diff of what would change.
+func (s *Service) LadderDryRun(ctx context.Context, gender string) (*Diff, error) {
+	diff := NewDiff()
+
+	err := s.ladder(ctx, &LadderSyncReport{}, diff, 0, gender)
+
 	if err != nil {
-		return nil, errors.Wrap(err, "loading the ladder failed")
+		return nil, err
+	}
+
+	diff.sort()
+
+	return diff, nil
+}
+
+// ladder syncs the ladder, if `diff` is set the
+// changes are added to it instead of being persisted.
+func (s *Service) ladder(ctx context.Context, report *LadderSyncReport, diff *Diff, runID int, gender string) error {
+	ranks, err := s.Client.Ladder(ctx, gender)
+
+	if err != nil {
+		return errors.Wrap(err, "loading the ladder failed")
 	}
 
 	persisted, err := s.PlayerRepo.ByGender(gender)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "loading persisted players failed")
+		return errors.Wrap(err, "loading persisted players failed")
 	}
 
 	syncInfos := Players(persisted, ranks...)
 
 	for _, info := range syncInfos {
+		if diff != nil {
+			var merged *volleynet.Player
+
+			if !info.IsNew {
+				merged = MergePlayer(info.OldPlayer, info.NewPlayer)
+			}
+
+			diff.addPlayer(info, merged)
+			continue
+		}
+
 		if info.IsNew {
 			_, err = s.PlayerRepo.New(info.NewPlayer)
 			report.NewPlayers++
@@ -39,14 +90,17 @@ func (s *Service) Ladder(gender string) (*LadderSyncReport, error) {
 			err = s.PlayerRepo.Update(merged)
 			report.UpdatedPlayers++
 
+			if err == nil && info.OldPlayer.LadderRank != merged.LadderRank {
+				s.publish(rankChangedEvent(runID, info.OldPlayer, merged))
+			}
 		}
 
 		if err != nil {
-			return nil, errors.Wrap(err, "sync player failed")
+			return errors.Wrap(err, "sync player failed")
 		}
 	}
 
-	return report, nil
+	return nil
 }
 
 // PlayerSyncInformation contains sync information for two `Player`s
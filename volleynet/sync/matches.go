@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+// matchesRefetchPeriod is how long after a tournament ended its matches are
+// loaded again while none are persisted, in case the livescoring was empty
+// or published late when the tournament was done.
+const matchesRefetchPeriod = 14 * 24 * time.Hour
+
+// MatchChanges lists the matches that are `New` during a sync job.
+type MatchChanges struct {
+	New []*volleynet.Match
+}
+
+// syncMatches loads the matches of all tournaments that are done, since
+// the matches of a tournament are final once the results are in. The
+// matches of tournaments that could not be loaded are skipped and their
+// errors are returned as `TournamentErrors`.
+func (s *Service) syncMatches(ctx context.Context, changes *MatchChanges, tournaments []*volleynet.TournamentInfo) error {
+	errs := TournamentErrors{}
+
+	for _, t := range tournaments {
+		if t.Status != volleynet.StatusDone {
+			continue
+		}
+
+		matches, err := s.Client.Matches(ctx, t)
+
+		if err != nil {
+			errs[t.ID] = errors.Wrap(err, "loading matches failed")
+			continue
+		}
+
+		changes.New = append(changes.New, matches...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// matchesMissing returns true if the persisted tournament `t` is done, ended
+// within the `matchesRefetchPeriod` and has no persisted matches.
+func (s *Service) matchesMissing(t *volleynet.Tournament, now time.Time) (bool, error) {
+	if t.Status != volleynet.StatusDone || now.Sub(t.End) > matchesRefetchPeriod {
+		return false, nil
+	}
+
+	matches, err := s.MatchRepo.ByTournament(t.ID)
+
+	if err != nil {
+		return false, errors.Wrap(err, "loading the persisted matches failed")
+	}
+
+	return len(matches) == 0, nil
+}
+
+func matchPlayers(matches []*volleynet.Match) []*volleynet.Player {
+	teams := []*volleynet.TournamentTeam{}
+
+	for _, m := range matches {
+		teams = append(teams,
+			&volleynet.TournamentTeam{Player1: m.Team1.Player1, Player2: m.Team1.Player2},
+			&volleynet.TournamentTeam{Player1: m.Team2.Player1, Player2: m.Team2.Player2},
+		)
+	}
+
+	return distinctPlayers(teams)
+}
+
+func (s *Service) persistMatches(changes *MatchChanges, players *PlayerChanges) error {
+	if len(changes.New) == 0 {
+		return nil
+	}
+
+	err := s.addPlayersIfNeeded(players, matchPlayers(changes.New))
+
+	if err != nil {
+		return err
+	}
+
+	err = s.MatchRepo.NewBatch(changes.New...)
+
+	return errors.Wrap(err, "persisting new matches failed")
+}
@@ -1,6 +1,11 @@
 package sync
 
-import "github.com/raphi011/scores-api/volleynet"
+import (
+	"time"
+
+	"github.com/raphi011/scores-api/volleynet"
+	"github.com/raphi011/scores-api/volleynet/scrape"
+)
 
 // MergeTournamentTeam merges two tournament teams depending on the syncType
 // and returns the new TournamentTeam.
@@ -73,7 +78,7 @@ func MergePlayer(persisted, current *volleynet.Player) *volleynet.Player {
 	merged := *persisted
 	merged.FirstName = current.FirstName
 	merged.LastName = current.LastName
-	merged.Birthday = current.Birthday
+	merged.Birthday = mergeBirthday(persisted.Birthday, current.Birthday)
 	merged.Gender = current.Gender
 	merged.TotalPoints = current.TotalPoints
 	merged.LadderRank = current.LadderRank
@@ -83,3 +88,39 @@ func MergePlayer(persisted, current *volleynet.Player) *volleynet.Player {
 
 	return &merged
 }
+
+// mergeBirthday prevents an exact birthday from being overwritten
+// by a birthday of which only the year is known.
+func mergeBirthday(persisted, current *time.Time) *time.Time {
+	if persisted != nil &&
+		current != nil &&
+		volleynet.IsYearOnlyBirthday(*current) &&
+		persisted.Year() == current.Year() {
+
+		return persisted
+	}
+
+	return current
+}
+
+// MergePlayerProfile complements a player with the data of the
+// player's profile page and returns the new player.
+func MergePlayerProfile(persisted *volleynet.Player, profile *scrape.ProfileData) *volleynet.Player {
+	merged := *persisted
+
+	if !profile.Birthday.IsZero() {
+		birthday := profile.Birthday
+		merged.Birthday = &birthday
+	}
+	if profile.Club != "" {
+		merged.Club = profile.Club
+	}
+	if profile.CountryUnion != "" {
+		merged.CountryUnion = profile.CountryUnion
+	}
+	if profile.License.Type != "" {
+		merged.License = profile.License.Type
+	}
+
+	return &merged
+}
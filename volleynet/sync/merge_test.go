@@ -2,6 +2,7 @@ package sync
 
 import (
 	"testing"
+	"time"
 
 	"github.com/raphi011/scores-api/volleynet"
 )
@@ -46,3 +47,17 @@ func TestMergeCanceledTournament(t *testing.T) {
 		t.Errorf("MergeTournament(old, new) did not update correctly")
 	}
 }
+
+func TestMergePlayerKeepsExactBirthday(t *testing.T) {
+	exactBirthday := time.Date(1991, time.May, 17, 0, 0, 0, 0, time.UTC)
+	yearOnlyBirthday := volleynet.YearOnlyBirthday(1991)
+
+	old := &volleynet.Player{Birthday: &exactBirthday}
+	new := &volleynet.Player{Birthday: &yearOnlyBirthday}
+
+	merged := MergePlayer(old, new)
+
+	if !merged.Birthday.Equal(exactBirthday) {
+		t.Errorf("MergePlayer(old, new) want birthday: %v, got: %v", exactBirthday, merged.Birthday)
+	}
+}
@@ -0,0 +1,144 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
+	"github.com/raphi011/scores-api/volleynet/scrape"
+)
+
+// PlayerProfileSyncReport contains metrics of a player profile sync job
+type PlayerProfileSyncReport struct {
+	UpdatedPlayers int
+	NewClubs       int
+}
+
+// PlayerErrors contains the errors of all players whose
+// profile could not be synced, mapped by the player id.
+type PlayerErrors map[int]error
+
+func (e PlayerErrors) Error() string {
+	return fmt.Sprintf("syncing %d player profile(s) failed: %s", len(e), errorList("player", e))
+}
+
+// PlayerProfiles complements all ranked players of a certain `gender` with the
+// data of their profile page, e.g. their exact birthday and club history. The
+// profiles are loaded with a pool of `s.Workers` workers, players whose profile
+// could not be synced are skipped and their errors are returned as
+// `PlayerErrors` together with the report.
+func (s *Service) PlayerProfiles(ctx context.Context, gender string) (*PlayerProfileSyncReport, error) {
+	run := newRun("player-profiles", fmt.Sprintf("gender=%s", gender))
+	report := &PlayerProfileSyncReport{}
+
+	if err := s.startRun(run); err != nil {
+		return nil, err
+	}
+
+	err := s.playerProfiles(ctx, report, gender)
+
+	run.UpdatedPlayers = report.UpdatedPlayers
+
+	err = s.finishRun(run, report.UpdatedPlayers+report.NewClubs > 0, err)
+
+	if _, ok := err.(PlayerErrors); err != nil && !ok {
+		return nil, err
+	}
+
+	return report, err
+}
+
+func (s *Service) playerProfiles(ctx context.Context, report *PlayerProfileSyncReport, gender string) error {
+	players, err := s.PlayerRepo.Ladder(gender)
+
+	if err != nil {
+		return errors.Wrap(err, "loading persisted players failed")
+	}
+
+	profiles := make([]*scrape.ProfileData, len(players))
+	errs := make([]error, len(players))
+
+	s.parallel(len(players), func(i int) {
+		profiles[i], errs[i] = s.Client.PlayerProfile(ctx, players[i].ID)
+	})
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	playerErrors := PlayerErrors{}
+
+	for i, player := range players {
+		if errs[i] != nil {
+			playerErrors[player.ID] = errors.Wrap(errs[i], "loading the profile failed")
+			continue
+		}
+
+		if err = s.playerProfile(report, player, profiles[i]); err != nil {
+			playerErrors[player.ID] = err
+		}
+	}
+
+	if len(playerErrors) > 0 {
+		return playerErrors
+	}
+
+	return nil
+}
+
+// playerProfile persists the `profile` of a player and its new clubs.
+func (s *Service) playerProfile(report *PlayerProfileSyncReport, player *volleynet.Player, profile *scrape.ProfileData) error {
+	err := s.PlayerRepo.Update(MergePlayerProfile(player, profile))
+
+	if err != nil {
+		return errors.Wrap(err, "sync player failed")
+	}
+
+	report.UpdatedPlayers++
+
+	persistedClubs, err := s.PlayerRepo.Clubs(player.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "loading persisted clubs failed")
+	}
+
+	newClubs := missingClubs(persistedClubs, profile.Clubs)
+
+	if len(newClubs) == 0 {
+		return nil
+	}
+
+	err = s.PlayerRepo.NewClubs(newClubs...)
+
+	if err != nil {
+		return errors.Wrap(err, "sync player clubs failed")
+	}
+
+	report.NewClubs += len(newClubs)
+
+	return nil
+}
+
+// missingClubs returns all `current` clubs that are not `persisted` yet.
+func missingClubs(persisted, current []*volleynet.PlayerClub) []*volleynet.PlayerClub {
+	missing := []*volleynet.PlayerClub{}
+
+	for _, c := range current {
+		found := false
+
+		for _, p := range persisted {
+			if p.Season == c.Season && p.Club == c.Club {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, c)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,92 @@
+package sync
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/raphi011/scores-api/volleynet"
+)
+
+func newRun(runType, parameters string) *volleynet.SyncRun {
+	return &volleynet.SyncRun{
+		Type:       runType,
+		Parameters: parameters,
+		Start:      time.Now(),
+	}
+}
+
+// startRun persists the `run` before the sync starts, so
+// that changes of the sync can refer to it. It has no end
+// until `finishRun` is called.
+func (s *Service) startRun(run *volleynet.SyncRun) error {
+	if s.SyncRunRepo == nil {
+		return nil
+	}
+
+	_, err := s.SyncRunRepo.New(run)
+
+	return errors.Wrap(err, "persisting the sync run failed")
+}
+
+// finishRun updates the `run` with the result of the sync, if both
+// the sync and updating the run fail the sync error is returned.
+// Runs that succeeded but haven't `changed` anything are deleted, most
+// runs don't find any changes and would crowd out the others.
+func (s *Service) finishRun(run *volleynet.SyncRun, changed bool, err error) error {
+	if s.SyncRunRepo == nil {
+		return err
+	}
+
+	if err == nil && !changed {
+		return errors.Wrap(s.SyncRunRepo.Delete(run), "deleting the unchanged sync run failed")
+	}
+
+	end := time.Now()
+	run.End = &end
+	run.DurationMS = end.Sub(run.Start).Milliseconds()
+
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	persistErr := s.SyncRunRepo.Update(run)
+
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(persistErr, "persisting the sync run failed")
+}
+
+// count adds the number of changes to the `run`.
+func (c *Changes) count(run *volleynet.SyncRun) {
+	run.NewTournaments = len(c.TournamentInfo.New)
+	run.UpdatedTournaments = len(c.TournamentInfo.Update)
+	run.DeletedTournaments = len(c.TournamentInfo.Delete)
+	run.NewTeams = len(c.Team.New)
+	run.UpdatedTeams = len(c.Team.Update)
+	run.DeletedTeams = len(c.Team.Delete)
+	run.NewPlayers = len(c.Player.New)
+}
+
+// changed returns true if the sync found any changes.
+func (c *Changes) changed() bool {
+	return len(c.TournamentInfo.New)+len(c.TournamentInfo.Update)+len(c.TournamentInfo.Delete)+
+		len(c.Team.New)+len(c.Team.Update)+len(c.Team.Delete)+
+		len(c.Player.New)+len(c.Match.New) > 0
+}
+
+// Runs returns `limit` sync runs starting at `offset`, the
+// latest run first, and the total number of runs.
+func (s *Service) Runs(offset, limit int) ([]*volleynet.SyncRun, int, error) {
+	runs, err := s.SyncRunRepo.Page(offset, limit)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.SyncRunRepo.Count()
+
+	return runs, total, err
+}
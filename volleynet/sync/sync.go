@@ -1,6 +1,9 @@
 package sync
 
 import (
+	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,27 +18,75 @@ import (
 type Changes struct {
 	TournamentInfo TournamentChanges
 	Team           TeamChanges
+	Match          MatchChanges
+	Player         PlayerChanges
 	ScrapeDuration time.Duration
 	Success        bool
+	RunID          int // the sync run the changes belong to
 }
 
 // Service allows loading and synchronizing of the volleynetpage.
 type Service struct {
+	MatchRepo      repo.MatchRepository
 	TeamRepo       repo.TeamRepository
 	TournamentRepo repo.TournamentRepository
 	PlayerRepo     repo.PlayerRepository
+	SyncRunRepo    repo.SyncRunRepository
+	ChangeRepo     repo.TournamentChangeRepository
+	UnitOfWork     repo.UnitOfWork
 
 	Client        client.Client
 	Subscriptions events.Publisher
+
+	Workers int // # of tournaments that are loaded concurrently
 }
 
 // Tournaments loads tournaments of a certain `gender`, `league` and `season` and
 // synchronizes + updates them (if necessary) in the repository.
-func (s *Service) Tournaments(gender, league string, season int) error {
-	report := &Changes{TournamentInfo: TournamentChanges{}, Team: TeamChanges{}}
+func (s *Service) Tournaments(ctx context.Context, gender, league string, season int) error {
+	run := newRun("tournaments", fmt.Sprintf("gender=%s, league=%s, season=%d", gender, league, season))
+	report := &Changes{TournamentInfo: TournamentChanges{}, Team: TeamChanges{}, Match: MatchChanges{}}
+
+	if err := s.startRun(run); err != nil {
+		return err
+	}
+
+	report.RunID = run.ID
+
 	s.publishStartScrapeEvent("tournaments", time.Now())
 
-	current, err := s.Client.Tournaments(gender, league, season)
+	err := s.tournaments(ctx, report, nil, gender, league, season)
+
+	report.count(run)
+
+	return s.finishRun(run, report.changed(), err)
+}
+
+// TournamentsDryRun runs the same sync as `Tournaments` but instead of
+// persisting the changes it returns the diff of what would change.
+// Tournaments that could not be loaded are missing in the diff and
+// are returned as `TournamentErrors`. The matches are not loaded,
+// the diff doesn't contain them.
+func (s *Service) TournamentsDryRun(ctx context.Context, gender, league string, season int) (*Diff, error) {
+	report := &Changes{TournamentInfo: TournamentChanges{}, Team: TeamChanges{}, Match: MatchChanges{}}
+	diff := NewDiff()
+
+	err := s.tournaments(ctx, report, diff, gender, league, season)
+
+	if _, ok := err.(TournamentErrors); err != nil && !ok {
+		return nil, err
+	}
+
+	diff.sort()
+
+	return diff, err
+}
+
+// tournaments syncs the tournaments, if `diff` is set the
+// changes are added to it instead of being persisted and
+// the matches are skipped.
+func (s *Service) tournaments(ctx context.Context, report *Changes, diff *Diff, gender, league string, season int) error {
+	current, err := s.Client.Tournaments(ctx, gender, league, season)
 
 	if err != nil {
 		return errors.Wrap(err, "loading the client tournament list failed")
@@ -43,6 +94,7 @@ func (s *Service) Tournaments(gender, league string, season int) error {
 
 	persistedTournaments := []*volleynet.Tournament{}
 	toDownload := []*volleynet.TournamentInfo{}
+	withoutMatches := []*volleynet.TournamentInfo{}
 
 	for _, t := range current {
 		persisted, err := s.TournamentRepo.Get(t.ID)
@@ -55,7 +107,17 @@ func (s *Service) Tournaments(gender, league string, season int) error {
 
 		syncInfo := Tournaments(persisted, t)
 
-		if syncInfo.Type == SyncTournamentNoUpdate {
+		if syncInfo.Type == SyncTournamentNoUpdate && diff != nil {
+			continue
+		} else if syncInfo.Type == SyncTournamentNoUpdate {
+			missing, err := s.matchesMissing(persisted, time.Now())
+
+			if err != nil {
+				return err
+			} else if missing {
+				withoutMatches = append(withoutMatches, t)
+			}
+
 			continue
 		} else if syncInfo.Type != SyncTournamentNew {
 			persisted.Teams, err = s.TeamRepo.ByTournament(t.ID)
@@ -70,32 +132,129 @@ func (s *Service) Tournaments(gender, league string, season int) error {
 		toDownload = append(toDownload, t)
 	}
 
-	if len(toDownload) == 0 {
+	if len(toDownload) == 0 && len(withoutMatches) == 0 {
 		return nil
 	}
 
-	currentTournaments := make([]*volleynet.Tournament, len(toDownload))
+	// tournaments that could not be loaded are skipped, the
+	// others are still synced before the errors are returned
+	currentTournaments, complementErr := s.complementTournaments(ctx, toDownload)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	s.syncTournaments(report, persistedTournaments, currentTournaments)
 
-	for i, t := range toDownload {
-		currentTournaments[i], err = s.Client.ComplementTournament(t)
+	if diff != nil {
+		err = s.diffChanges(diff, report)
 
 		if err != nil {
-			// remove it from the tournaments for now
-			currentTournaments = append(currentTournaments[:i], currentTournaments[i+1:]...)
+			return errors.Wrap(err, "diff failed")
 		}
+
+		return mergeTournamentErrors(complementErr)
 	}
 
-	s.syncTournaments(report, persistedTournaments, currentTournaments)
+	for _, t := range currentTournaments {
+		withoutMatches = append(withoutMatches, &t.TournamentInfo)
+	}
+
+	// like the tournaments the matches that could not be loaded are skipped
+	matchesErr := s.syncMatches(ctx, &report.Match, withoutMatches)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 
 	err = s.persistChanges(report)
 
 	s.publishEndScrapeEvent(report, time.Now())
 
-	return errors.Wrap(err, "sync failed")
+	if err != nil {
+		return errors.Wrap(err, "sync failed")
+	}
+
+	return mergeTournamentErrors(complementErr, matchesErr)
+}
+
+func (s *Service) diffChanges(diff *Diff, report *Changes) error {
+	err := s.addNewPlayers(diff, report.Team.New)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.addTournaments(diff, &report.TournamentInfo)
+
+	if err != nil {
+		return err
+	}
+
+	return s.addTeams(diff, &report.Team)
 }
 
+// persistChanges persists the changes of each tournament in its own
+// transaction, so a failed sync never leaves a tournament with only
+// part of its teams. The events of a tournament are published once
+// its transaction is committed.
 func (s *Service) persistChanges(report *Changes) error {
-	err := s.addMissingPlayers(report.Team.New)
+	for _, changes := range report.byTournament() {
+		changes := changes
+		var evts []events.Event
+
+		err := s.inTransaction(func(tx *Service) error {
+			var err error
+			evts, err = tx.tournamentEvents(changes)
+
+			if err != nil {
+				return err
+			}
+
+			return tx.persistTournamentChanges(changes)
+		})
+
+		if err != nil {
+			return errors.Wrapf(err, "persisting tournament %d failed", changes.tournamentID)
+		}
+
+		report.Player.New = append(report.Player.New, changes.Player.New...)
+
+		s.publish(evts...)
+	}
+
+	return nil
+}
+
+// inTransaction runs `work` with a copy of the service whose
+// repositories share a single transaction.
+func (s *Service) inTransaction(work func(tx *Service) error) error {
+	if s.UnitOfWork == nil {
+		return work(s)
+	}
+
+	return s.UnitOfWork.Do(func(repos *repo.Repositories) error {
+		tx := *s
+		tx.MatchRepo = repos.MatchRepo
+		tx.PlayerRepo = repos.PlayerRepo
+		tx.TeamRepo = repos.TeamRepo
+		tx.TournamentRepo = repos.TournamentRepo
+		tx.SyncRunRepo = repos.SyncRunRepo
+		tx.ChangeRepo = repos.ChangeRepo
+		tx.UnitOfWork = nil
+
+		return work(&tx)
+	})
+}
+
+func (s *Service) persistTournamentChanges(report *tournamentChanges) error {
+	err := s.addMissingPlayers(&report.Player, report.Team.New)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.recordChanges(report)
 
 	if err != nil {
 		return err
@@ -107,5 +266,81 @@ func (s *Service) persistChanges(report *Changes) error {
 		return err
 	}
 
-	return s.persistTeams(&report.Team)
+	err = s.persistTeams(&report.Team)
+
+	if err != nil {
+		return err
+	}
+
+	return s.persistMatches(&report.Match, &report.Player)
+}
+
+// tournamentChanges are the changes of a single tournament.
+type tournamentChanges struct {
+	Changes
+	tournamentID int
+}
+
+// byTournament splits the changes by tournament, ordered by the tournament id.
+func (c *Changes) byTournament() []*tournamentChanges {
+	tournaments := map[int]*tournamentChanges{}
+
+	get := func(tournamentID int) *Changes {
+		changes, ok := tournaments[tournamentID]
+
+		if !ok {
+			changes = &tournamentChanges{tournamentID: tournamentID}
+			changes.RunID = c.RunID
+			tournaments[tournamentID] = changes
+		}
+
+		return &changes.Changes
+	}
+
+	for _, t := range c.TournamentInfo.New {
+		changes := get(t.ID)
+		changes.TournamentInfo.New = append(changes.TournamentInfo.New, t)
+	}
+
+	for _, t := range c.TournamentInfo.Update {
+		changes := get(t.ID)
+		changes.TournamentInfo.Update = append(changes.TournamentInfo.Update, t)
+	}
+
+	for _, t := range c.TournamentInfo.Delete {
+		changes := get(t.ID)
+		changes.TournamentInfo.Delete = append(changes.TournamentInfo.Delete, t)
+	}
+
+	for _, t := range c.Team.New {
+		changes := get(t.TournamentID)
+		changes.Team.New = append(changes.Team.New, t)
+	}
+
+	for _, t := range c.Team.Update {
+		changes := get(t.TournamentID)
+		changes.Team.Update = append(changes.Team.Update, t)
+	}
+
+	for _, t := range c.Team.Delete {
+		changes := get(t.TournamentID)
+		changes.Team.Delete = append(changes.Team.Delete, t)
+	}
+
+	for _, m := range c.Match.New {
+		changes := get(m.TournamentID)
+		changes.Match.New = append(changes.Match.New, m)
+	}
+
+	sorted := make([]*tournamentChanges, 0, len(tournaments))
+
+	for _, changes := range tournaments {
+		sorted = append(sorted, changes)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].tournamentID < sorted[j].tournamentID
+	})
+
+	return sorted
 }
@@ -1,13 +1,20 @@
 package sync
 
 import (
+	"context"
+	"errors"
 	"os"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	pkgerrors "github.com/pkg/errors"
 
+	"github.com/raphi011/scores-api"
 	"github.com/raphi011/scores-api/events"
+	"github.com/raphi011/scores-api/repo"
 	"github.com/raphi011/scores-api/repo/sql"
 	"github.com/raphi011/scores-api/test"
 	"github.com/raphi011/scores-api/volleynet"
@@ -21,9 +28,13 @@ func syncMock(t *testing.T) (*mocks.ClientMock, *Service, *sqlx.DB) {
 
 	service := &Service{
 		Client:         clientMock,
+		MatchRepo:      repos.MatchRepo,
 		PlayerRepo:     repos.PlayerRepo,
 		TournamentRepo: repos.TournamentRepo,
 		TeamRepo:       repos.TeamRepo,
+		SyncRunRepo:    repos.SyncRunRepo,
+		ChangeRepo:     repos.ChangeRepo,
+		UnitOfWork:     repos.UnitOfWork,
 		Subscriptions:  &events.Broker{},
 	}
 
@@ -44,7 +55,7 @@ func TestSyncLadder(t *testing.T) {
 
 	clientMock.On("Ladder", gender).Return(clientPlayers, nil)
 
-	report, err := service.Ladder(gender)
+	report, err := service.Ladder(context.Background(), gender)
 
 	test.Check(t, "service.Ladder() err: %v", err)
 	test.Assert(t, "Service.Ladder(\"M\") want: .UpdatedPlayers = 1, got: %d", report.UpdatedPlayers == 1, report.UpdatedPlayers)
@@ -52,7 +63,7 @@ func TestSyncLadder(t *testing.T) {
 
 func TestSyncTournamentInformation(t *testing.T) {
 	response, _ := os.Open("../testdata/upcoming.html")
-	tournament, _ := scrape.Tournament(response, test.MustParseDate("30.05.2018"), &volleynet.TournamentInfo{Status: volleynet.StatusUpcoming, ID: 22231})
+	tournament, _ := scrape.Tournament(response, test.MustParseDate("30.05.2018"), &volleynet.TournamentInfo{Status: volleynet.StatusUpcoming, ID: 22231}, nil)
 
 	syncInfos := Tournaments(tournament, &volleynet.TournamentInfo{ID: 22231, Status: volleynet.StatusUpcoming})
 
@@ -94,7 +105,588 @@ func TestSyncTournaments(t *testing.T) {
 	clientMock.On("Tournaments", gender, league, season).Return(clientTournaments, nil)
 	clientMock.On("ComplementTournament", clientTournaments[0]).Return(clientFullTournament[0], nil)
 
-	err := service.Tournaments("M", "amateur-league", 2018)
+	err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
 
 	test.Check(t, "service.Tournaments() err: %v", err)
+
+	runs, err := service.SyncRunRepo.Page(0, 10)
+	test.Check(t, "syncRunRepo.Page() err: %v", err)
+	test.Assert(t, "service.Tournaments() want: 1 persisted run, got: %d", len(runs) == 1, len(runs))
+	test.Assert(t, "service.Tournaments() want: .UpdatedTournaments = 1, got: %d", runs[0].UpdatedTournaments == 1, runs[0].UpdatedTournaments)
+	test.Assert(t, "service.Tournaments() want: finished run with .End, got none", runs[0].End != nil)
+}
+
+func TestSyncWithoutChangesDeletesTheRun(t *testing.T) {
+	clientMock, service, _ := syncMock(t)
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{}, nil)
+
+	err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.Tournaments() err: %v", err)
+
+	runs, err := service.SyncRunRepo.Page(0, 10)
+	test.Check(t, "syncRunRepo.Page() err: %v", err)
+	test.Assert(t, "service.Tournaments() want: no persisted run, got: %d", len(runs) == 0, len(runs))
+}
+
+func TestSyncDoneTournamentMatches(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+
+	players := sql.CreatePlayers(t, db,
+		sql.P{ID: 1},
+		sql.P{ID: 2},
+	)
+
+	sql.CreateTournaments(t, db,
+		sql.T{ID: 1, Status: volleynet.StatusUpcoming},
+	)
+
+	clientTournament := &volleynet.TournamentInfo{
+		ID:     1,
+		Status: volleynet.StatusUpcoming,
+		Start:  time.Now(),
+		End:    time.Now(),
+	}
+
+	clientFullTournament := &volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{
+			ID:     1,
+			Status: volleynet.StatusDone,
+			Start:  time.Now(),
+			End:    time.Now(),
+		},
+		Teams: []*volleynet.TournamentTeam{},
+	}
+
+	clientMatches := []*volleynet.Match{{
+		TournamentID: 1,
+		MatchNr:      1,
+		Team1:        &volleynet.MatchTeam{Player1: players[0], Player2: players[1]},
+		Team2: &volleynet.MatchTeam{
+			Player1: &volleynet.Player{ID: 3},
+			Player2: &volleynet.Player{ID: 4},
+		},
+		Set1:   volleynet.MatchSet{Team1: 21, Team2: 10},
+		Set2:   volleynet.MatchSet{Team1: 21, Team2: 12},
+		Winner: 1,
+	}}
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{clientTournament}, nil)
+	clientMock.On("ComplementTournament", clientTournament).Return(clientFullTournament, nil)
+	clientMock.On("Matches", &clientFullTournament.TournamentInfo).Return(clientMatches, nil)
+
+	err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.Tournaments() err: %v", err)
+
+	matches, err := service.MatchRepo.ByTournament(1)
+	test.Check(t, "matchRepo.ByTournament() err: %v", err)
+	test.Assert(t, "service.Tournaments() want: 1 persisted match, got: %d", len(matches) == 1, len(matches))
+}
+
+func TestSyncMatchesErrorsSkipTheTournament(t *testing.T) {
+	clientMock, service, _ := syncMock(t)
+
+	clientTournaments := []*volleynet.TournamentInfo{
+		{ID: 1, Status: volleynet.StatusDone, Start: time.Now(), End: time.Now()},
+		{ID: 2, Status: volleynet.StatusDone, Start: time.Now(), End: time.Now()},
+	}
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return(clientTournaments, nil)
+
+	for _, t := range clientTournaments {
+		clientMock.On("ComplementTournament", t).Return(&volleynet.Tournament{
+			TournamentInfo: *t,
+			Teams:          []*volleynet.TournamentTeam{},
+		}, nil)
+	}
+
+	clientMock.On("Matches", clientTournaments[0]).Return([]*volleynet.Match{}, nil)
+	clientMock.On("Matches", clientTournaments[1]).Return(([]*volleynet.Match)(nil), errors.New("timeout"))
+
+	err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+
+	tournamentErrors, ok := err.(TournamentErrors)
+	test.Assert(t, "service.Tournaments() want: TournamentErrors, got: %v", ok, err)
+	test.Assert(t, "service.Tournaments() want: an error of tournament 2, got: %v", tournamentErrors[2] != nil, err)
+
+	for _, id := range []int{1, 2} {
+		_, err = service.TournamentRepo.Get(id)
+		test.Check(t, "tournamentRepo.Get() err: %v", err)
+	}
+}
+
+func TestSyncRefetchesMissingMatches(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+
+	players := sql.CreatePlayers(t, db,
+		sql.P{ID: 1},
+		sql.P{ID: 2},
+		sql.P{ID: 3},
+		sql.P{ID: 4},
+	)
+
+	sql.CreateTournaments(t, db,
+		sql.T{ID: 1, Status: volleynet.StatusDone},
+	)
+
+	clientTournament := &volleynet.TournamentInfo{
+		ID:     1,
+		Status: volleynet.StatusDone,
+		Start:  time.Now(),
+		End:    time.Now(),
+	}
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{clientTournament}, nil)
+	clientMock.On("Matches", clientTournament).Return([]*volleynet.Match{{
+		TournamentID: 1,
+		MatchNr:      1,
+		Team1:        &volleynet.MatchTeam{Player1: players[0], Player2: players[1]},
+		Team2:        &volleynet.MatchTeam{Player1: players[2], Player2: players[3]},
+		Winner:       1,
+	}}, nil).Once()
+
+	err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.Tournaments() err: %v", err)
+
+	matches, err := service.MatchRepo.ByTournament(1)
+	test.Check(t, "matchRepo.ByTournament() err: %v", err)
+	test.Assert(t, "service.Tournaments() want: 1 persisted match, got: %d", len(matches) == 1, len(matches))
+
+	// the matches are persisted now, they are not loaded again
+	err = service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.Tournaments() err: %v", err)
+
+	clientMock.AssertNumberOfCalls(t, "Matches", 1)
+}
+
+func TestSyncPlayerProfiles(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+	gender := "M"
+
+	sql.CreatePlayers(t, db,
+		sql.P{ID: 1, LadderRank: 1, Gender: gender},
+	)
+
+	clientMock.On("PlayerProfile", 1).Return(&scrape.ProfileData{
+		PlayerInfo: scrape.PlayerInfo{ID: 1, Birthday: test.MustParseDate("17.05.1991")},
+		Club:       "Beachvolleyball Club Wien",
+		License:    scrape.License{Type: "A"},
+		Clubs: []*volleynet.PlayerClub{
+			{PlayerID: 1, Season: "2018", Club: "Beachvolleyball Club Wien"},
+		},
+	}, nil)
+
+	report, err := service.PlayerProfiles(context.Background(), gender)
+	test.Check(t, "service.PlayerProfiles() err: %v", err)
+	test.Assert(t, "service.PlayerProfiles() want: .NewClubs = 1, got: %d", report.NewClubs == 1, report.NewClubs)
+
+	player, err := service.PlayerRepo.Get(1)
+	test.Check(t, "playerRepo.Get() err: %v", err)
+	test.Assert(t, "service.PlayerProfiles() want: .License = A, got: %s", player.License == "A", player.License)
+}
+
+func TestSyncPlayerProfilesCollectsErrors(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+	service.Workers = 2
+	gender := "M"
+
+	sql.CreatePlayers(t, db,
+		sql.P{ID: 1, LadderRank: 1, Gender: gender},
+		sql.P{ID: 2, LadderRank: 2, Gender: gender},
+		sql.P{ID: 3, LadderRank: 3, Gender: gender},
+	)
+
+	clientMock.On("PlayerProfile", 1).Return(&scrape.ProfileData{PlayerInfo: scrape.PlayerInfo{ID: 1}}, nil)
+	clientMock.On("PlayerProfile", 2).Return((*scrape.ProfileData)(nil), errors.New("timeout"))
+	clientMock.On("PlayerProfile", 3).Return(&scrape.ProfileData{PlayerInfo: scrape.PlayerInfo{ID: 3}}, nil)
+
+	report, err := service.PlayerProfiles(context.Background(), gender)
+
+	playerErrors, ok := err.(PlayerErrors)
+	test.Assert(t, "service.PlayerProfiles() want: PlayerErrors, got: %v", ok, err)
+	test.Assert(t, "service.PlayerProfiles() want: an error of player 2, got: %v", len(playerErrors) == 1 && playerErrors[2] != nil, err)
+	test.Assert(t, "service.PlayerProfiles() want: .UpdatedPlayers = 2, got: %d", report.UpdatedPlayers == 2, report.UpdatedPlayers)
+}
+
+func TestSyncTournamentsCollectsErrors(t *testing.T) {
+	clientMock, service, _ := syncMock(t)
+	service.Workers = 2
+
+	clientTournaments := []*volleynet.TournamentInfo{
+		{ID: 1, Status: volleynet.StatusUpcoming, Start: time.Now(), End: time.Now()},
+		{ID: 2, Status: volleynet.StatusUpcoming, Start: time.Now(), End: time.Now()},
+		{ID: 3, Status: volleynet.StatusUpcoming, Start: time.Now(), End: time.Now()},
+	}
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return(clientTournaments, nil)
+	clientMock.On("ComplementTournament", clientTournaments[0]).Return(&volleynet.Tournament{
+		TournamentInfo: *clientTournaments[0],
+		Teams:          []*volleynet.TournamentTeam{},
+	}, nil)
+	clientMock.On("ComplementTournament", clientTournaments[1]).Return((*volleynet.Tournament)(nil), errors.New("timeout"))
+	clientMock.On("ComplementTournament", clientTournaments[2]).Return((*volleynet.Tournament)(nil), errors.New("timeout"))
+
+	err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+
+	tournamentErrors, ok := err.(TournamentErrors)
+
+	test.Assert(t, "service.Tournaments() want: TournamentErrors, got: %v", ok, err)
+	test.Assert(t, "service.Tournaments() want: 2 errors, got: %d", len(tournamentErrors) == 2, len(tournamentErrors))
+
+	runs, err := service.SyncRunRepo.Page(0, 10)
+	test.Check(t, "syncRunRepo.Page() err: %v", err)
+	test.Assert(t, "service.Tournaments() want: run with error, got: %q", runs[0].Error != "", runs[0].Error)
+
+	_, err = service.TournamentRepo.Get(1)
+	test.Check(t, "tournamentRepo.Get() err: %v", err)
+}
+
+func TestTournamentsDryRun(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+
+	clientTournament := &volleynet.TournamentInfo{
+		ID:     1,
+		Status: volleynet.StatusUpcoming,
+		Start:  time.Now(),
+		End:    time.Now(),
+	}
+
+	sql.CreateTournaments(t, db,
+		sql.T{ID: 1, Status: volleynet.StatusUpcoming},
+	)
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{clientTournament}, nil)
+	clientMock.On("ComplementTournament", clientTournament).Return(&volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{
+			ID:     1,
+			Status: volleynet.StatusUpcoming,
+			Name:   "New name",
+			Start:  clientTournament.Start,
+			End:    clientTournament.End,
+		},
+		Teams: []*volleynet.TournamentTeam{{
+			TournamentID: 1,
+			Player1:      &volleynet.Player{ID: 1},
+			Player2:      &volleynet.Player{ID: 2},
+		}},
+	}, nil)
+
+	diff, err := service.TournamentsDryRun(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.TournamentsDryRun() err: %v", err)
+
+	test.Assert(t, "service.TournamentsDryRun() want: 1 tournament diff, got: %d", len(diff.Tournaments) == 1, len(diff.Tournaments))
+	test.Assert(t, "service.TournamentsDryRun() want: 1 new team, got: %d", len(diff.Teams) == 1, len(diff.Teams))
+	test.Assert(t, "service.TournamentsDryRun() want: 2 new players, got: %d", len(diff.Players) == 2, len(diff.Players))
+
+	nameChanged := false
+
+	for _, f := range diff.Tournaments[0].Fields {
+		nameChanged = nameChanged || (f.Field == "Name" && f.New == "New name")
+	}
+
+	test.Assert(t, "service.TournamentsDryRun() want: changed .Name field, got: %+v", nameChanged, diff.Tournaments[0].Fields)
+
+	tournament, err := service.TournamentRepo.Get(1)
+	test.Check(t, "tournamentRepo.Get() err: %v", err)
+	test.Equal(t, "service.TournamentsDryRun() want: unchanged .Name %q, got: %q", "", tournament.Name)
+
+	teams, err := service.TeamRepo.ByTournament(1)
+	test.Check(t, "teamRepo.ByTournament() err: %v", err)
+	test.Assert(t, "service.TournamentsDryRun() want: no persisted teams, got: %d", len(teams) == 0, len(teams))
+
+	runs, err := service.SyncRunRepo.Page(0, 10)
+	test.Check(t, "syncRunRepo.Page() err: %v", err)
+	test.Assert(t, "service.TournamentsDryRun() want: no persisted runs, got: %d", len(runs) == 0, len(runs))
+}
+
+func TestTournamentsDryRunSkipsMatches(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+
+	clientTournament := &volleynet.TournamentInfo{
+		ID:     1,
+		Status: volleynet.StatusUpcoming,
+		Start:  time.Now(),
+		End:    time.Now(),
+	}
+
+	sql.CreateTournaments(t, db,
+		sql.T{ID: 1, Status: volleynet.StatusUpcoming},
+	)
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{clientTournament}, nil)
+	clientMock.On("ComplementTournament", clientTournament).Return(&volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{
+			ID:     1,
+			Status: volleynet.StatusDone,
+			Start:  clientTournament.Start,
+			End:    clientTournament.End,
+		},
+		Teams: []*volleynet.TournamentTeam{},
+	}, nil)
+
+	_, err := service.TournamentsDryRun(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.TournamentsDryRun() err: %v", err)
+
+	clientMock.AssertNumberOfCalls(t, "Matches", 0)
+}
+
+func TestLadderDryRun(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+	gender := "M"
+
+	sql.CreatePlayers(t, db,
+		sql.P{ID: 1, TotalPoints: 100, LadderRank: 96, Gender: gender},
+	)
+
+	clientMock.On("Ladder", gender).Return([]*volleynet.Player{
+		{ID: 1, TotalPoints: 125, LadderRank: 96, Gender: gender},
+		{ID: 2, TotalPoints: 50, LadderRank: 200, Gender: gender},
+	}, nil)
+
+	diff, err := service.LadderDryRun(context.Background(), gender)
+	test.Check(t, "service.LadderDryRun() err: %v", err)
+	test.Assert(t, "service.LadderDryRun() want: 2 player diffs, got: %d", len(diff.Players) == 2, len(diff.Players))
+
+	test.Compare(t, "service.LadderDryRun() .Players[0] diff: %s", EntityDiff{
+		Key:    "1",
+		Action: DiffUpdate,
+		Fields: []FieldChange{{Field: "TotalPoints", Old: 100, New: 125}},
+	}, diff.Players[0])
+
+	player, err := service.PlayerRepo.Get(1)
+	test.Check(t, "playerRepo.Get() err: %v", err)
+	test.Equal(t, "service.LadderDryRun() want: unchanged .TotalPoints %d, got: %d", 100, player.TotalPoints)
+}
+
+// failingUnitOfWork fails to persist the `n`th new team of a transaction.
+type failingUnitOfWork struct {
+	repo.UnitOfWork
+	n int
+}
+
+type failingTeamRepo struct {
+	repo.TeamRepository
+	n int
+}
+
+func (r *failingTeamRepo) New(t *volleynet.TournamentTeam) (*volleynet.TournamentTeam, error) {
+	r.n--
+
+	if r.n == 0 {
+		return nil, errors.New("connection lost")
+	}
+
+	return r.TeamRepository.New(t)
+}
+
+func (u *failingUnitOfWork) Do(work func(repos *repo.Repositories) error) error {
+	return u.UnitOfWork.Do(func(repos *repo.Repositories) error {
+		repos.TeamRepo = &failingTeamRepo{TeamRepository: repos.TeamRepo, n: u.n}
+
+		return work(repos)
+	})
+}
+
+func TestSyncTournamentsRollsBack(t *testing.T) {
+	clientMock, service, _ := syncMock(t)
+	service.UnitOfWork = &failingUnitOfWork{UnitOfWork: service.UnitOfWork, n: 2}
+
+	clientTournament := &volleynet.TournamentInfo{
+		ID:     1,
+		Status: volleynet.StatusUpcoming,
+		Start:  time.Now(),
+		End:    time.Now(),
+	}
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{clientTournament}, nil)
+	clientMock.On("ComplementTournament", clientTournament).Return(&volleynet.Tournament{
+		TournamentInfo: *clientTournament,
+		Teams: []*volleynet.TournamentTeam{
+			{TournamentID: 1, Player1: &volleynet.Player{ID: 1}, Player2: &volleynet.Player{ID: 2}},
+			{TournamentID: 1, Player1: &volleynet.Player{ID: 3}, Player2: &volleynet.Player{ID: 4}},
+		},
+	}, nil)
+
+	err := service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+	test.Assert(t, "service.Tournaments() want err, got nil", err != nil)
+
+	_, err = service.TournamentRepo.Get(1)
+	test.Assert(t, "service.Tournaments() want tournament to be rolled back, got err: %v", pkgerrors.Cause(err) == scores.ErrNotFound, err)
+
+	teams, err := service.TeamRepo.ByTournament(1)
+	test.Check(t, "teamRepo.ByTournament() err: %v", err)
+	test.Assert(t, "service.Tournaments() want teams to be rolled back, got: %d", len(teams) == 0, len(teams))
+
+	_, err = service.PlayerRepo.Get(1)
+	test.Assert(t, "service.Tournaments() want players to be rolled back, got err: %v", pkgerrors.Cause(err) == scores.ErrNotFound, err)
+}
+
+func TestSyncTournamentsRecordsChanges(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+
+	players := sql.CreatePlayers(t, db,
+		sql.P{ID: 1},
+		sql.P{ID: 2},
+	)
+
+	sql.CreateTournaments(t, db,
+		sql.T{ID: 1, Status: volleynet.StatusUpcoming},
+	)
+
+	sql.CreateTeams(t, db,
+		sql.TT{TournamentID: 1, Player1: players[0], Player2: players[1], Seed: 2},
+	)
+
+	persisted, err := service.TournamentRepo.Get(1)
+	test.Check(t, "tournamentRepo.Get() err: %v", err)
+
+	endRegistration := time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	clientTournament := &volleynet.TournamentInfo{
+		ID:     1,
+		Status: volleynet.StatusUpcoming,
+		Start:  persisted.Start,
+		End:    persisted.End,
+	}
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{clientTournament}, nil)
+	clientMock.On("ComplementTournament", clientTournament).Return(&volleynet.Tournament{
+		TournamentInfo:  *clientTournament,
+		EndRegistration: &endRegistration,
+		Teams: []*volleynet.TournamentTeam{
+			{TournamentID: 1, Player1: players[0], Player2: players[1], Seed: 1},
+		},
+	}, nil)
+
+	err = service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.Tournaments() err: %v", err)
+
+	runs, err := service.SyncRunRepo.Page(0, 1)
+	test.Check(t, "syncRunRepo.Page() err: %v", err)
+
+	changes, err := service.ChangeRepo.ByTournament(1)
+	test.Check(t, "changeRepo.ByTournament() err: %v", err)
+
+	fields := map[string]*volleynet.TournamentChange{}
+
+	for _, c := range changes {
+		test.Equal(t, "service.Tournaments() want change .SyncRunID: %d, got: %d", runs[0].ID, c.SyncRunID)
+		fields[c.Team+"/"+c.Field] = c
+	}
+
+	endRegistrationChange, ok := fields["/EndRegistration"]
+	test.Assert(t, "service.Tournaments() want .EndRegistration change, got: %v", ok, fields)
+	test.Equal(t, "service.Tournaments() want .NewValue: %q, got: %q", "2018-06-01T00:00:00Z", endRegistrationChange.NewValue)
+
+	seedChange, ok := fields["1-1-2/Seed"]
+	test.Assert(t, "service.Tournaments() want team .Seed change, got: %v", ok, fields)
+	test.Equal(t, "service.Tournaments() want .OldValue: %q, got: %q", "2", seedChange.OldValue)
+}
+
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(event events.Event) {
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) names(prefix string) []string {
+	names := []string{}
+
+	for _, e := range p.events {
+		if strings.HasPrefix(e.Name, prefix) {
+			names = append(names, e.Name)
+		}
+	}
+
+	return names
+}
+
+func TestSyncTournamentsPublishesEvents(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+	publisher := &recordingPublisher{}
+	service.Subscriptions = publisher
+
+	players := sql.CreatePlayers(t, db,
+		sql.P{ID: 1},
+		sql.P{ID: 2},
+		sql.P{ID: 3},
+		sql.P{ID: 4},
+	)
+
+	sql.CreateTournaments(t, db,
+		sql.T{ID: 1, Status: volleynet.StatusUpcoming},
+	)
+
+	sql.CreateTeams(t, db,
+		sql.TT{TournamentID: 1, Player1: players[0], Player2: players[1]},
+	)
+
+	persisted, err := service.TournamentRepo.Get(1)
+	test.Check(t, "tournamentRepo.Get() err: %v", err)
+
+	updated := &volleynet.TournamentInfo{ID: 1, Status: volleynet.StatusUpcoming, Start: persisted.Start, End: persisted.End}
+	created := &volleynet.TournamentInfo{ID: 2, Status: volleynet.StatusUpcoming, Start: time.Now(), End: time.Now()}
+
+	clientMock.On("Tournaments", "M", "amateur-league", 2018).Return([]*volleynet.TournamentInfo{updated, created}, nil)
+	clientMock.On("ComplementTournament", updated).Return(&volleynet.Tournament{
+		TournamentInfo: volleynet.TournamentInfo{ID: 1, Status: volleynet.StatusUpcoming, Start: persisted.Start, End: persisted.End, RegistrationOpen: true},
+		Teams: []*volleynet.TournamentTeam{
+			{TournamentID: 1, Player1: players[2], Player2: players[3]},
+		},
+	}, nil)
+	clientMock.On("ComplementTournament", created).Return(&volleynet.Tournament{
+		TournamentInfo: *created,
+		Teams: []*volleynet.TournamentTeam{
+			{TournamentID: 2, Player1: players[0], Player2: players[1]},
+		},
+	}, nil)
+
+	err = service.Tournaments(context.Background(), "M", "amateur-league", 2018)
+	test.Check(t, "service.Tournaments() err: %v", err)
+
+	want := []string{
+		RegistrationOpenedEventType,
+		TeamSignedUpEventType,
+		TeamDeregisteredEventType,
+		TournamentCreatedEventType,
+	}
+
+	got := append(publisher.names("volleynet/tournament/"), publisher.names("volleynet/team/")...)
+	sort.Strings(want)
+	sort.Strings(got)
+
+	test.Compare(t, "service.Tournaments() unexpected events:\n%s", want, got)
+
+	for _, e := range publisher.events {
+		if e.Name == TeamSignedUpEventType {
+			team := e.Body.(TeamEvent).Team
+			test.Assert(t, "service.Tournaments() want signed up team 3-4, got: %s", team.Player1.ID == 3 && team.Player2.ID == 4, artificialTeamKey(team))
+		}
+	}
+}
+
+func TestSyncLadderPublishesRankChanges(t *testing.T) {
+	clientMock, service, db := syncMock(t)
+	publisher := &recordingPublisher{}
+	service.Subscriptions = publisher
+
+	sql.CreatePlayers(t, db,
+		sql.P{ID: 1, LadderRank: 96, Gender: "M"},
+		sql.P{ID: 2, LadderRank: 10, Gender: "M"},
+	)
+
+	clientMock.On("Ladder", "M").Return([]*volleynet.Player{
+		{ID: 1, LadderRank: 60, Gender: "M"},
+		{ID: 2, LadderRank: 10, Gender: "M"},
+	}, nil)
+
+	_, err := service.Ladder(context.Background(), "M")
+	test.Check(t, "service.Ladder() err: %v", err)
+
+	test.Compare(t, "service.Ladder() unexpected events:\n%s", []string{RankChangedEventType}, publisher.names("volleynet/ladder/"))
+
+	event := publisher.events[len(publisher.events)-1].Body.(RankChangedEvent)
+	test.Assert(t, "service.Ladder() want rank change 96 -> 60, got: %d -> %d", event.OldRank == 96 && event.NewRank == 60, event.OldRank, event.NewRank)
 }
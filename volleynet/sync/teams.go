@@ -16,6 +16,11 @@ type TeamChanges struct {
 	Update []*volleynet.TournamentTeam
 }
 
+// PlayerChanges lists the players that are `New` during a sync job
+type PlayerChanges struct {
+	New []*volleynet.Player
+}
+
 func artificialTeamKey(team *volleynet.TournamentTeam) string {
 	return fmt.Sprintf("%d-%d-%d", team.TournamentID, team.Player1.ID, team.Player2.ID)
 }
@@ -86,15 +91,21 @@ func (s *Service) persistTeams(changes *TeamChanges) error {
 	return nil
 }
 
-func (s *Service) addMissingPlayers(teams []*volleynet.TournamentTeam) error {
-	players := distinctPlayers(teams)
+func (s *Service) addMissingPlayers(changes *PlayerChanges, teams []*volleynet.TournamentTeam) error {
+	return s.addPlayersIfNeeded(changes, distinctPlayers(teams))
+}
 
+func (s *Service) addPlayersIfNeeded(changes *PlayerChanges, players []*volleynet.Player) error {
 	for _, p := range players {
-		err := s.addPlayerIfNeeded(p)
+		added, err := s.addPlayerIfNeeded(p)
 
 		if err != nil {
 			return errors.Wrap(err, "addMissingPlayers failed")
 		}
+
+		if added {
+			changes.New = append(changes.New, p)
+		}
 	}
 
 	return nil
@@ -120,16 +131,16 @@ func distinctPlayers(teams []*volleynet.TournamentTeam) []*volleynet.Player {
 	return distinct
 }
 
-func (s *Service) addPlayerIfNeeded(player *volleynet.Player) error {
+func (s *Service) addPlayerIfNeeded(player *volleynet.Player) (bool, error) {
 	_, err := s.PlayerRepo.Get(player.ID)
 
 	if errors.Cause(err) == scores.ErrNotFound {
 		_, err = s.PlayerRepo.New(player)
 
-		return errors.Wrap(err, "addPlayerIfNeeded")
+		return err == nil, errors.Wrap(err, "addPlayerIfNeeded")
 	} else if err != nil {
-		return errors.Wrap(err, "addPlayerIfNeeded")
+		return false, errors.Wrap(err, "addPlayerIfNeeded")
 	}
 
-	return nil
+	return false, nil
 }
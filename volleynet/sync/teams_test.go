@@ -46,7 +46,7 @@ func TestAddMissingPlayers(t *testing.T) {
 		},
 	}
 
-	err := service.addMissingPlayers(teams)
+	err := service.addMissingPlayers(&PlayerChanges{}, teams)
 
 	test.Check(t, "addMissingPlayers() failed: %v", err)
 }
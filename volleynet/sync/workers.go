@@ -0,0 +1,41 @@
+package sync
+
+import (
+	"sync"
+)
+
+// parallel calls `work` for every index from 0 to `n`-1 with a pool of
+// `s.Workers` workers and returns once all calls returned.
+func (s *Service) parallel(n int, work func(i int)) {
+	workers := s.Workers
+
+	if workers <= 0 {
+		workers = 1
+	}
+
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				work(index)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+}
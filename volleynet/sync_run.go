@@ -0,0 +1,30 @@
+package volleynet
+
+import (
+	"time"
+
+	"github.com/raphi011/scores-api"
+)
+
+// SyncRun is the persisted report of a single sync job run.
+type SyncRun struct {
+	scores.M
+	scores.Track
+
+	Type       string     `json:"type"`
+	Parameters string     `json:"parameters"`
+	Start      time.Time  `json:"start" db:"start_date"`
+	End        *time.Time `json:"end" db:"end_date"` // nil while the run is in progress
+	DurationMS int64      `json:"durationMs" db:"duration_ms"`
+
+	NewTournaments     int `json:"newTournaments" db:"new_tournaments"`
+	UpdatedTournaments int `json:"updatedTournaments" db:"updated_tournaments"`
+	DeletedTournaments int `json:"deletedTournaments" db:"deleted_tournaments"`
+	NewTeams           int `json:"newTeams" db:"new_teams"`
+	UpdatedTeams       int `json:"updatedTeams" db:"updated_teams"`
+	DeletedTeams       int `json:"deletedTeams" db:"deleted_teams"`
+	NewPlayers         int `json:"newPlayers" db:"new_players"`
+	UpdatedPlayers     int `json:"updatedPlayers" db:"updated_players"`
+
+	Error string `json:"error"`
+}
@@ -0,0 +1,22 @@
+package volleynet
+
+import (
+	"time"
+
+	"github.com/raphi011/scores-api"
+)
+
+// TournamentChange is the change of a single field of a tournament or one of
+// its teams during a sync, `Team` is empty if the tournament itself changed.
+type TournamentChange struct {
+	scores.M
+	scores.Track
+
+	TournamentID int       `json:"tournamentId" db:"tournament_id"`
+	Team         string    `json:"team"`
+	Field        string    `json:"field"`
+	OldValue     string    `json:"oldValue" db:"old_value"`
+	NewValue     string    `json:"newValue" db:"new_value"`
+	SyncRunID    int       `json:"syncRunId" db:"sync_run_id"`
+	ChangedAt    time.Time `json:"changedAt" db:"changed_at"`
+}
@@ -0,0 +1,44 @@
+package scores
+
+import (
+	"time"
+)
+
+// Webhook is an url that the events matching one of its
+// `Events` patterns are posted to, signed with its `Secret`.
+type Webhook struct {
+	M
+	Track
+	URL    string `json:"url"`
+	Events string `json:"events"` // comma separated event names that may end with a wildcard
+	Secret string `json:"-"`      // only returned when the webhook is created
+}
+
+// the states of a `WebhookDelivery`.
+const (
+	// DeliveryPending is a delivery that has not succeeded yet and will be retried.
+	DeliveryPending = "pending"
+	// DeliverySending is a delivery that an instance is sending, if it doesn't
+	// finish before its next attempt the delivery is sent again.
+	DeliverySending = "sending"
+	// DeliveryDelivered is a delivery that was accepted by the webhook.
+	DeliveryDelivered = "delivered"
+	// DeliveryDead is a delivery that failed too often and is not retried anymore.
+	DeliveryDead = "dead"
+)
+
+// WebhookDelivery is the delivery of an event to a webhook.
+type WebhookDelivery struct {
+	M
+	Track
+	WebhookID      int        `json:"webhookId" db:"webhook_id"`
+	EventID        uint64     `json:"eventId" db:"event_id"`
+	EventName      string     `json:"eventName" db:"event_name"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"` // can be `DeliveryPending`, `DeliverySending`, `DeliveryDelivered` or `DeliveryDead`
+	Attempts       int        `json:"attempts"`
+	NextAttempt    *time.Time `json:"nextAttempt" db:"next_attempt"`
+	ResponseStatus int        `json:"responseStatus" db:"response_status"`
+	LastError      string     `json:"lastError" db:"last_error"`
+	DeliveredAt    *time.Time `json:"deliveredAt" db:"delivered_at"`
+}